@@ -0,0 +1,394 @@
+package arxiv
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/xmlfmt"
+)
+
+const endpoint = "http://export.arxiv.org/api/query"
+
+const (
+	KeyID              = "arxiv_id"
+	KeyVersion         = "arxiv_version"
+	KeyPrimaryCategory = "primary_category"
+	KeyCategories      = "categories"
+	KeyLinkAbstract    = lit.KeyLinkAbstract
+	KeyLinkPDF         = "link_pdf"
+	KeyDOI             = lit.KeyDOI
+	KeyJournalRef      = "journal_ref"
+	KeyComment         = "comment"
+	KeyAuthors         = lit.KeyAuthors
+	KeyAffiliation     = "affiliation"
+	KeyUpdated         = "updated"
+)
+
+// Entries are stored as standalone documents, hence they have to carry
+// the namespace declarations of the feed they were extracted from.
+const (
+	entryOpen  = `<entry xmlns="http://www.w3.org/2005/Atom" xmlns:arxiv="http://arxiv.org/schemas/atom">`
+	entryClose = `</entry>`
+)
+
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func (c Client) DefaultPerPage() int {
+	return 100
+}
+
+func (c Client) newRequest(ctx context.Context, q url.Values) *http.Request {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		panic(err)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Accept", "application/atom+xml")
+	return req
+}
+
+func (c Client) newSearchRequest(ctx context.Context, src lit.Request) *http.Request {
+	q := url.Values{}
+	q.Set("search_query", src.Query)
+	q.Set("start", fmt.Sprintf("%d", src.PerPage*src.Page))
+	q.Set("max_results", fmt.Sprintf("%d", src.PerPage))
+	return c.newRequest(ctx, q)
+}
+
+type rawEntry struct {
+	ID    string `xml:"id"`
+	Inner []byte `xml:",innerxml"`
+}
+
+type feed struct {
+	Total   int        `xml:"totalResults"`
+	Entries []rawEntry `xml:"entry"`
+}
+
+// arXiv reports errors as a feed containing a single entry whose id points
+// to the error documentation.
+func (f feed) Err() error {
+	for _, v := range f.Entries {
+		if !strings.Contains(v.ID, "/api/errors") {
+			continue
+		}
+		e, err := parseEntry(v.Blob())
+		if err != nil {
+			return fmt.Errorf("arxiv error: %s", v.ID)
+		}
+		return fmt.Errorf("arxiv error: %s", e.Summary)
+	}
+	return nil
+}
+
+func (e rawEntry) Blob() lit.Blob {
+	var buf bytes.Buffer
+	buf.WriteString(entryOpen)
+	buf.Write(e.Inner)
+	buf.WriteString(entryClose)
+	return lit.Blob(buf.Bytes())
+}
+
+func (c Client) query(ctx context.Context, req *http.Request) (feed, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return feed{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return feed{}, lit.NewStatusError(resp, "")
+	}
+
+	var f feed
+	if err := xml.NewDecoder(resp.Body).Decode(&f); err != nil {
+		return feed{}, err
+	}
+	if err := f.Err(); err != nil {
+		return feed{}, err
+	}
+	return f, nil
+}
+
+func (c Client) GetLiterature(ctx context.Context, req lit.Request) (lit.Response, error) {
+	f, err := c.query(ctx, c.newSearchRequest(ctx, req))
+	if err != nil {
+		return lit.Response{}, err
+	}
+	blobs := make([]lit.Blob, len(f.Entries))
+	for i, v := range f.Entries {
+		blobs[i] = v.Blob()
+	}
+	return lit.Response{
+		Req:   req,
+		Blobs: blobs,
+	}, nil
+}
+
+func (c Client) GetMaxLiterature(ctx context.Context, req lit.Request) (int, error) {
+	req.Page = 0
+	req.PerPage = 0
+	f, err := c.query(ctx, c.newSearchRequest(ctx, req))
+	if err != nil {
+		return 0, err
+	}
+	return f.Total, nil
+}
+
+func (c Client) GetRateLimit() time.Duration {
+	// https://info.arxiv.org/help/api/tou.html asks for no more than one
+	// request every three seconds.
+	return time.Second * 3
+}
+
+func (c Client) ConcurrencyLimit() int {
+	return 1
+}
+
+func (c Client) PrettyPrint(b lit.Blob, dst *bytes.Buffer) error {
+	return xmlfmt.Indent(dst, []byte(b), "", "\t")
+}
+
+type link struct {
+	Href  string `xml:"href,attr"`
+	Rel   string `xml:"rel,attr"`
+	Title string `xml:"title,attr"`
+}
+
+type category struct {
+	Term string `xml:"term,attr"`
+}
+
+type author struct {
+	Name        string `xml:"name"`
+	Affiliation string `xml:"affiliation"`
+}
+
+type entry struct {
+	ID              string     `xml:"id"`
+	Published       string     `xml:"published"`
+	Updated         string     `xml:"updated"`
+	Title           string     `xml:"title"`
+	Summary         string     `xml:"summary"`
+	Authors         []author   `xml:"author"`
+	Links           []link     `xml:"link"`
+	PrimaryCategory category   `xml:"primary_category"`
+	Categories      []category `xml:"category"`
+	DOI             string     `xml:"doi"`
+	JournalRef      string     `xml:"journal_ref"`
+	Comment         string     `xml:"comment"`
+}
+
+func parseEntry(b lit.Blob) (entry, error) {
+	var e entry
+	if err := xml.Unmarshal([]byte(b), &e); err != nil {
+		return entry{}, err
+	}
+	return e, nil
+}
+
+// Matches both new (2101.00001v2) and old (hep-th/9901001v1) style
+// identifiers, capturing the version separately.
+var idRgx = regexp.MustCompile(`abs/(.+?)(v\d+)?$`)
+
+func (e entry) ArxivID() (id, version string) {
+	m := idRgx.FindStringSubmatch(e.ID)
+	if m == nil {
+		return e.ID, ""
+	}
+	return m[1], m[2]
+}
+
+func (e entry) CoverDate() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, e.Published)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse published date: %w", err)
+	}
+	return t, nil
+}
+
+func (e entry) Creator() string {
+	if len(e.Authors) == 0 {
+		return ""
+	}
+	return e.Authors[0].Name
+}
+
+func (e entry) AuthorList() []lit.Author {
+	if len(e.Authors) == 0 {
+		return nil
+	}
+	authors := make([]lit.Author, len(e.Authors))
+	for i, v := range e.Authors {
+		authors[i] = lit.Author{Name: v.Name, Affiliation: v.Affiliation}
+	}
+	return authors
+}
+
+func (e entry) link(match func(link) bool) string {
+	for _, v := range e.Links {
+		if match(v) {
+			return v.Href
+		}
+	}
+	return ""
+}
+
+func (e entry) Values() map[string]string {
+	id, version := e.ArxivID()
+
+	categories := make([]string, len(e.Categories))
+	for i, v := range e.Categories {
+		categories[i] = v.Term
+	}
+	authors := make([]string, len(e.Authors))
+	affiliations := []string{}
+	for i, v := range e.Authors {
+		authors[i] = v.Name
+		if v.Affiliation != "" {
+			affiliations = append(affiliations, v.Affiliation)
+		}
+	}
+
+	return map[string]string{
+		KeyID:              id,
+		KeyVersion:         version,
+		KeyPrimaryCategory: e.PrimaryCategory.Term,
+		KeyCategories:      strings.Join(categories, ", "),
+		KeyLinkAbstract: e.link(func(l link) bool {
+			return l.Rel == "alternate"
+		}),
+		KeyLinkPDF: e.link(func(l link) bool {
+			return l.Title == "pdf"
+		}),
+		KeyDOI:         e.DOI,
+		KeyJournalRef:  collapse(e.JournalRef),
+		KeyComment:     collapse(e.Comment),
+		KeyAuthors:     strings.Join(authors, " and "),
+		KeyAffiliation: strings.Join(affiliations, "; "),
+		KeyUpdated:     e.Updated,
+	}
+}
+
+// Atom text fields are hard wrapped.
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func (c Client) ParsePublication(b lit.Blob) (lit.Publication, error) {
+	e, err := parseEntry(b)
+	if err != nil {
+		return lit.Publication{}, err
+	}
+	coverDate, err := e.CoverDate()
+	if err != nil {
+		return lit.Publication{}, fmt.Errorf("entry %s: %w", e.ID, err)
+	}
+
+	id, _ := e.ArxivID()
+	p := lit.Publication{
+		Title:     collapse(e.Title),
+		CoverDate: coverDate,
+		Creator:   e.Creator(),
+		Authors:   e.AuthorList(),
+		IDs: lit.Identifiers{
+			DOI:   e.DOI,
+			ArXiv: id,
+		},
+		// Whether or not it was published since, the entry is the
+		// preprint.
+		Type:   lit.TypePreprint,
+		Values: e.Values(),
+	}
+	if summary := collapse(e.Summary); summary != "" {
+		p.Abstract = &lit.Abstract{Text: summary}
+	}
+	return p, nil
+}
+
+func (c Client) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	if p.Abstract != nil {
+		return *p.Abstract, nil
+	}
+
+	id := p.Values[KeyID]
+	if id == "" {
+		return lit.Abstract{}, fmt.Errorf("publication %q has no arXiv identifier", p.Title)
+	}
+	f, err := c.query(ctx, c.newRequest(ctx, url.Values{"id_list": {id}}))
+	if err != nil {
+		return lit.Abstract{}, err
+	}
+	if len(f.Entries) == 0 {
+		return lit.Abstract{}, fmt.Errorf("arXiv entry %s not found", id)
+	}
+	e, err := parseEntry(f.Entries[0].Blob())
+	if err != nil {
+		return lit.Abstract{}, err
+	}
+	return lit.Abstract{
+		Text: collapse(e.Summary),
+	}, nil
+}
+
+// HTTPClient returns the client used to talk to the API, see
+// lit.HTTPLibrary.
+func (c Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+func (c Client) GetName() string {
+	return "arXiv"
+}
+
+// NativeID returns the arXiv identifier of p, without version.
+func (c Client) NativeID(p lit.Publication) string {
+	return p.Values[KeyID]
+}
+
+func (c Client) ToBibTeX(p lit.Publication) bibtex.Reference {
+	archive := "arXiv"
+	return bibtex.Misc{
+		Entry:         p.BibTeXEntry(),
+		Note:          p.Value(KeyJournalRef),
+		Eprint:        p.Value(KeyID),
+		ArchivePrefix: &archive,
+		PrimaryClass:  p.Value(KeyPrimaryCategory),
+	}
+}
+
+func (c Client) ReferenceLink(p lit.Publication) string {
+	if l := p.Values[KeyLinkAbstract]; l != "" {
+		return l
+	}
+	return "https://arxiv.org/abs/" + p.Values[KeyID]
+}
+
+func NewClient() Client {
+	tr := &http.Transport{
+		MaxIdleConns:       10,
+		IdleConnTimeout:    15 * time.Second,
+		DisableCompression: false,
+	}
+
+	return Client{
+		endpoint:   endpoint,
+		httpClient: &http.Client{Transport: tr},
+	}
+}
@@ -0,0 +1,273 @@
+package arxiv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/littest"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c := NewClient()
+	c.endpoint = srv.URL
+	return c
+}
+
+func TestGetLiterature(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if have, want := q.Get("search_query"), "all:fpga AND all:cnn"; have != want {
+			t.Errorf("search_query: have %q, want %q", have, want)
+		}
+		if have, want := q.Get("start"), "20"; have != want {
+			t.Errorf("start: have %q, want %q", have, want)
+		}
+		fmt.Fprint(w, feedPage)
+	})
+
+	resp, err := c.GetLiterature(context.Background(), lit.Request{
+		Query:   "all:fpga AND all:cnn",
+		Page:    2,
+		PerPage: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 2 {
+		t.Fatalf("blobs: have %d, want 2", resp.Len())
+	}
+
+	p, err := c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Accelerating Binarized Convolutional Neural Networks with Software-Programmable FPGAs"; p.Title != want {
+		t.Fatalf("title: have %q, want %q", p.Title, want)
+	}
+	if want := "Ritchie Zhao"; p.Creator != want {
+		t.Fatalf("creator: have %q, want %q", p.Creator, want)
+	}
+	values := map[string]string{
+		KeyID:              "1702.01234",
+		KeyVersion:         "v2",
+		KeyPrimaryCategory: "cs.DC",
+		KeyCategories:      "cs.DC, cs.LG",
+		KeyDOI:             "10.1145/3020078.3021741",
+		KeyLinkAbstract:    "http://arxiv.org/abs/1702.01234v2",
+		KeyLinkPDF:         "http://arxiv.org/pdf/1702.01234v2",
+		KeyAuthors:         "Ritchie Zhao and Weinan Song",
+		KeyAffiliation:     "Cornell University",
+	}
+	for k, want := range values {
+		if have := p.Values[k]; have != want {
+			t.Errorf("%s: have %q, want %q", k, have, want)
+		}
+	}
+	if p.IDs != (lit.Identifiers{DOI: "10.1145/3020078.3021741", ArXiv: "1702.01234"}) || p.Type != lit.TypePreprint || len(p.Authors) != 2 {
+		t.Errorf("unexpected metadata %+v, %s, %+v", p.IDs, p.Type, p.Authors)
+	}
+
+	// The summary is embedded: no request needed.
+	abs, err := c.GetAbstract(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Convolutional neural networks (CNN) are the current state-of-the-art for many computer vision tasks."; abs.Text != want {
+		t.Fatalf("abstract: have %q, want %q", abs.Text, want)
+	}
+
+	ref := c.ToBibTeX(p)
+	if ref.EntryType() != bibtex.EntryTypeMisc {
+		t.Fatalf("entry type: have %q, want %q", ref.EntryType(), bibtex.EntryTypeMisc)
+	}
+	fields := map[string]string{
+		"eprint":        "1702.01234",
+		"archivePrefix": "arXiv",
+		"primaryClass":  "cs.DC",
+		"author":        "Ritchie Zhao and Weinan Song",
+		"year":          "2017",
+	}
+	for k, want := range fields {
+		if have := ref.Fields()[k]; have != want {
+			t.Errorf("bibtex %s: have %q, want %q", k, have, want)
+		}
+	}
+
+	p, err = c.ParsePublication(resp.Blobs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := p.Values[KeyID], "cs/0112017"; have != want {
+		t.Fatalf("old style id: have %q, want %q", have, want)
+	}
+}
+
+func TestGetMaxLiterature(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, feedPage)
+	})
+	have, err := c.GetMaxLiterature(context.Background(), lit.Request{Query: "all:fpga"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 431; have != want {
+		t.Fatalf("max literature: have %d, want %d", have, want)
+	}
+}
+
+func TestGetAbstractByID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.URL.Query().Get("id_list"), "1702.01234"; have != want {
+			t.Errorf("id_list: have %q, want %q", have, want)
+		}
+		fmt.Fprint(w, feedPage)
+	})
+	abs, err := c.GetAbstract(context.Background(), lit.Publication{
+		Values: map[string]string{KeyID: "1702.01234"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(abs.Text, "Convolutional neural networks") {
+		t.Fatalf("abstract: have %q", abs.Text)
+	}
+}
+
+func TestErrorFeed(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, errorFeed)
+	})
+	_, err := c.GetLiterature(context.Background(), lit.Request{Query: "all:fpga", PerPage: 10})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "arxiv error: incorrect id format for 1234.12345v"; err.Error() != want {
+		t.Fatalf("error: have %q, want %q", err, want)
+	}
+}
+
+func TestPrettyPrint(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, feedPage)
+	})
+	resp, err := c.GetLiterature(context.Background(), lit.Request{Query: "all:fpga", PerPage: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := c.PrettyPrint(resp.Blobs[0], &buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"\t<arxiv:primary_category xmlns:arxiv=\"http://arxiv.org/schemas/atom\" term=\"cs.DC\" scheme=\"http://arxiv.org/schemas/atom\"></arxiv:primary_category>",
+		"\t<author>\n\t\t<name>Ritchie Zhao</name>",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("pretty print does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestConformance(t *testing.T) {
+	start := strings.Index(feedPage, "<entry>")
+	end := strings.Index(feedPage, "</entry>") + len("</entry>")
+	head := strings.Replace(feedPage[:start], ">431<", ">42<", 1)
+	entries := make([]string, 42)
+	for i := range entries {
+		e := strings.ReplaceAll(feedPage[start:end], "1702.01234", fmt.Sprintf("1702.%05d", i))
+		e = strings.ReplaceAll(e, "3020078.3021741", fmt.Sprintf("3020078.%d", i))
+		entries[i] = strings.Replace(e, "</title>", fmt.Sprintf(", part %d</title>", i), 1)
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		from, _ := strconv.Atoi(q.Get("start"))
+		max, _ := strconv.Atoi(q.Get("max_results"))
+		to := from + max
+		if to > len(entries) {
+			to = len(entries)
+		}
+		fmt.Fprint(w, head+strings.Join(entries[from:to], "\n")+"\n</feed>\n")
+	})
+	littest.RunConformance(t, c, littest.Fixture{Query: "all:fpga", PerPage: 10, Max: 42})
+}
+
+// Trimmed down response recorded from
+// http://export.arxiv.org/api/query?search_query=all:fpga%20AND%20all:cnn
+const feedPage = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <link href="http://arxiv.org/api/query?search_query%3Dall%3Afpga%26id_list%3D%26start%3D0%26max_results%3D10" rel="self" type="application/atom+xml"/>
+  <title type="html">ArXiv Query: search_query=all:fpga&amp;id_list=&amp;start=0&amp;max_results=10</title>
+  <id>http://arxiv.org/api/0ofcMbSZFBAtAjR7VQDbHvVN0Tg</id>
+  <updated>2022-01-10T00:00:00-05:00</updated>
+  <opensearch:totalResults xmlns:opensearch="http://a9.com/-/spec/opensearch/1.1/">431</opensearch:totalResults>
+  <opensearch:startIndex xmlns:opensearch="http://a9.com/-/spec/opensearch/1.1/">0</opensearch:startIndex>
+  <opensearch:itemsPerPage xmlns:opensearch="http://a9.com/-/spec/opensearch/1.1/">10</opensearch:itemsPerPage>
+  <entry>
+    <id>http://arxiv.org/abs/1702.01234v2</id>
+    <updated>2017-03-01T18:40:47Z</updated>
+    <published>2017-02-04T10:24:42Z</published>
+    <title>Accelerating Binarized Convolutional Neural Networks with
+  Software-Programmable FPGAs</title>
+    <summary>  Convolutional neural networks (CNN) are the current state-of-the-art for
+many computer vision tasks.
+</summary>
+    <author>
+      <name>Ritchie Zhao</name>
+      <arxiv:affiliation xmlns:arxiv="http://arxiv.org/schemas/atom">Cornell University</arxiv:affiliation>
+    </author>
+    <author>
+      <name>Weinan Song</name>
+    </author>
+    <arxiv:doi xmlns:arxiv="http://arxiv.org/schemas/atom">10.1145/3020078.3021741</arxiv:doi>
+    <link title="doi" href="http://dx.doi.org/10.1145/3020078.3021741" rel="related"/>
+    <arxiv:comment xmlns:arxiv="http://arxiv.org/schemas/atom">10 pages</arxiv:comment>
+    <arxiv:journal_ref xmlns:arxiv="http://arxiv.org/schemas/atom">FPGA '17</arxiv:journal_ref>
+    <link href="http://arxiv.org/abs/1702.01234v2" rel="alternate" type="text/html"/>
+    <link title="pdf" href="http://arxiv.org/pdf/1702.01234v2" rel="related" type="application/pdf"/>
+    <arxiv:primary_category xmlns:arxiv="http://arxiv.org/schemas/atom" term="cs.DC" scheme="http://arxiv.org/schemas/atom"/>
+    <category term="cs.DC" scheme="http://arxiv.org/schemas/atom"/>
+    <category term="cs.LG" scheme="http://arxiv.org/schemas/atom"/>
+  </entry>
+  <entry>
+    <id>http://arxiv.org/abs/cs/0112017v1</id>
+    <updated>2001-12-19T10:00:00Z</updated>
+    <published>2001-12-19T10:00:00Z</published>
+    <title>Neural networks on reconfigurable hardware</title>
+    <summary>An old style identifier.</summary>
+    <author>
+      <name>John Doe</name>
+    </author>
+    <link href="http://arxiv.org/abs/cs/0112017v1" rel="alternate" type="text/html"/>
+    <arxiv:primary_category xmlns:arxiv="http://arxiv.org/schemas/atom" term="cs.NE" scheme="http://arxiv.org/schemas/atom"/>
+    <category term="cs.NE" scheme="http://arxiv.org/schemas/atom"/>
+  </entry>
+</feed>
+`
+
+const errorFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <opensearch:totalResults xmlns:opensearch="http://a9.com/-/spec/opensearch/1.1/">1</opensearch:totalResults>
+  <entry>
+    <id>http://arxiv.org/api/errors#incorrect_id_format_for_1234.12345v</id>
+    <title>Error</title>
+    <summary>incorrect id format for 1234.12345v</summary>
+    <updated>2022-01-10T00:00:00-05:00</updated>
+    <link href="http://arxiv.org/api/errors#incorrect_id_format_for_1234.12345v" rel="alternate" type="text/html"/>
+    <author>
+      <name>arXiv api core</name>
+    </author>
+  </entry>
+</feed>
+`
@@ -134,6 +134,7 @@ type Article struct {
 
 	// optional fields
 	Volume    *string
+	Number    *string
 	PageRange *string
 }
 
@@ -144,6 +145,9 @@ func (a Article) Fields() map[string]string {
 	if vol := a.Volume; vol != nil {
 		m["volume"] = *vol
 	}
+	if n := a.Number; n != nil {
+		m["number"] = *n
+	}
 	if r := a.PageRange; r != nil {
 		m["pages"] = *r
 	}
@@ -268,7 +272,10 @@ type Misc struct {
 	Entry
 
 	// optional fields
-	Note *string
+	Note          *string
+	Eprint        *string // e.g. 2101.00001
+	ArchivePrefix *string // e.g. arXiv
+	PrimaryClass  *string // e.g. cs.LG
 }
 
 func (a Misc) Fields() map[string]string {
@@ -277,6 +284,15 @@ func (a Misc) Fields() map[string]string {
 	if note := a.Note; note != nil {
 		m["note"] = *note
 	}
+	if e := a.Eprint; e != nil {
+		m["eprint"] = *e
+	}
+	if p := a.ArchivePrefix; p != nil {
+		m["archivePrefix"] = *p
+	}
+	if c := a.PrimaryClass; c != nil {
+		m["primaryClass"] = *c
+	}
 	return m
 }
 
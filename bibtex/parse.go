@@ -0,0 +1,323 @@
+package bibtex
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Record is an entry as read from a .bib file. Field names are lower case,
+// values have their outer delimiters removed and @string macros expanded,
+// but are otherwise left as written, LaTeX included.
+type Record struct {
+	Type   EntryType
+	Key    string
+	Fields map[string]string
+}
+
+var months = map[string]string{
+	"jan": "January", "feb": "February", "mar": "March", "apr": "April",
+	"may": "May", "jun": "June", "jul": "July", "aug": "August",
+	"sep": "September", "oct": "October", "nov": "November", "dec": "December",
+}
+
+type scanner struct {
+	r    *bufio.Reader
+	line int
+}
+
+func (s *scanner) read() (rune, error) {
+	r, _, err := s.r.ReadRune()
+	if r == '\n' {
+		s.line++
+	}
+	return r, err
+}
+
+func (s *scanner) unread(r rune) {
+	if r == '\n' {
+		s.line--
+	}
+	s.r.UnreadRune()
+}
+
+func (s *scanner) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", s.line, fmt.Sprintf(format, args...))
+}
+
+func (s *scanner) skipSpace() (rune, error) {
+	for {
+		r, err := s.read()
+		if err != nil {
+			return 0, err
+		}
+		if !unicode.IsSpace(r) {
+			return r, nil
+		}
+	}
+}
+
+func (s *scanner) expect(want rune) error {
+	r, err := s.skipSpace()
+	if err != nil {
+		return s.errorf("expected %q: %v", want, err)
+	}
+	if r != want {
+		return s.errorf("expected %q, found %q", want, r)
+	}
+	return nil
+}
+
+// ident reads names, keys and bare values.
+func (s *scanner) ident() (string, error) {
+	r, err := s.skipSpace()
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for {
+		if unicode.IsSpace(r) || strings.ContainsRune("{}(),=#\"", r) {
+			s.unread(r)
+			return b.String(), nil
+		}
+		b.WriteRune(r)
+		if r, err = s.read(); err != nil {
+			return b.String(), nil
+		}
+	}
+}
+
+// braced reads up to the brace closing an already consumed opening one.
+func (s *scanner) braced() (string, error) {
+	var b strings.Builder
+	depth := 1
+	for {
+		r, err := s.read()
+		if err != nil {
+			return "", s.errorf("unbalanced braces: %v", err)
+		}
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return b.String(), nil
+			}
+		}
+		b.WriteRune(r)
+	}
+}
+
+// quoted reads up to the quote closing an already consumed opening one.
+// Quotes within braces do not count.
+func (s *scanner) quoted() (string, error) {
+	var b strings.Builder
+	depth := 0
+	for {
+		r, err := s.read()
+		if err != nil {
+			return "", s.errorf("unterminated string: %v", err)
+		}
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '"':
+			if depth == 0 {
+				return b.String(), nil
+			}
+		}
+		b.WriteRune(r)
+	}
+}
+
+// value reads a field value, made of one or more parts joined with #.
+func (s *scanner) value(macros map[string]string) (string, error) {
+	var b strings.Builder
+	for {
+		r, err := s.skipSpace()
+		if err != nil {
+			return "", s.errorf("missing value: %v", err)
+		}
+		switch r {
+		case '{':
+			v, err := s.braced()
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(v)
+		case '"':
+			v, err := s.quoted()
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(v)
+		default:
+			s.unread(r)
+			name, err := s.ident()
+			if err != nil {
+				return "", err
+			}
+			if name == "" {
+				return "", s.errorf("missing value")
+			}
+			if v, ok := macros[strings.ToLower(name)]; ok {
+				b.WriteString(v)
+			} else {
+				// Numbers and undefined macros.
+				b.WriteString(name)
+			}
+		}
+
+		r, err = s.skipSpace()
+		if err != nil {
+			return b.String(), nil
+		}
+		if r != '#' {
+			s.unread(r)
+			return b.String(), nil
+		}
+	}
+}
+
+// fields reads name = value pairs up to the closing delimiter.
+func (s *scanner) fields(close rune, macros map[string]string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for {
+		r, err := s.skipSpace()
+		if err != nil {
+			return nil, s.errorf("unterminated entry: %v", err)
+		}
+		if r == close {
+			return fields, nil
+		}
+		if r == ',' {
+			continue
+		}
+		s.unread(r)
+
+		name, err := s.ident()
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			return nil, s.errorf("missing field name")
+		}
+		if err := s.expect('='); err != nil {
+			return nil, err
+		}
+		v, err := s.value(macros)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		fields[strings.ToLower(name)] = v
+	}
+}
+
+// Parse reads all entries of a .bib file. Text outside of entries is
+// ignored as comment, as are @comment and @preamble entries. @string
+// macros, including the predefined month abbreviations, are expanded.
+func Parse(r io.Reader) ([]Record, error) {
+	s := &scanner{r: bufio.NewReader(r), line: 1}
+	macros := make(map[string]string, len(months))
+	for k, v := range months {
+		macros[k] = v
+	}
+
+	records := []Record{}
+	for {
+		// Skip to the next entry.
+		r, err := s.read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if r != '@' {
+			continue
+		}
+
+		typ, err := s.ident()
+		if err != nil {
+			return nil, s.errorf("missing entry type: %v", err)
+		}
+		typ = strings.ToLower(typ)
+
+		open, err := s.skipSpace()
+		if err != nil {
+			return nil, s.errorf("missing entry body: %v", err)
+		}
+		close := '}'
+		switch open {
+		case '{':
+		case '(':
+			close = ')'
+		default:
+			return nil, s.errorf("expected { or ( after @%s, found %q", typ, open)
+		}
+
+		switch typ {
+		case "comment", "preamble":
+			if _, err := s.braced(); err != nil {
+				return nil, err
+			}
+			continue
+		case "string":
+			fields, err := s.fields(close, macros)
+			if err != nil {
+				return nil, fmt.Errorf("@string: %w", err)
+			}
+			for k, v := range fields {
+				macros[k] = v
+			}
+			continue
+		}
+
+		key, err := s.ident()
+		if err != nil {
+			return nil, err
+		}
+		fields := map[string]string{}
+		switch r, err := s.skipSpace(); {
+		case err != nil:
+			return nil, s.errorf("@%s{%s: unterminated entry: %v", typ, key, err)
+		case r == close:
+			// Entries made of the key only.
+		case r == ',':
+			if fields, err = s.fields(close, macros); err != nil {
+				return nil, fmt.Errorf("@%s{%s: %w", typ, key, err)
+			}
+		default:
+			return nil, s.errorf("@%s{%s: expected ',', found %q", typ, key, r)
+		}
+		records = append(records, Record{
+			Type:   EntryType(typ),
+			Key:    key,
+			Fields: fields,
+		})
+	}
+}
+
+var latexReplacer = strings.NewReplacer(
+	`\&`, "&",
+	`\%`, "%",
+	`\$`, "$",
+	`\_`, "_",
+	`\#`, "#",
+	`~`, " ",
+	`--`, "-",
+	"{", "",
+	"}", "",
+)
+
+// Clean turns a field value into plain text: braces are dropped, common
+// escapes are resolved and whitespace is collapsed. Accent commands are
+// kept as they are.
+func Clean(v string) string {
+	return strings.Join(strings.Fields(latexReplacer.Replace(v)), " ")
+}
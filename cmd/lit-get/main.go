@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
-	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -14,16 +16,14 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jecoz/edb"
 	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/libs"
 	"github.com/jecoz/lit/log"
-	"github.com/jecoz/lit/scopus"
-)
-
-var (
-	scopusKey = os.Getenv("SCOPUS_API_KEY")
+	"github.com/jecoz/lit/middleware"
 )
 
 var (
 	edbPath = flag.String("edb", "lit.edb", "Event database file. Everything will be stored here.")
+	libName = flag.String("lib", libs.Default, libs.Usage())
 )
 
 const (
@@ -54,11 +54,25 @@ var keys = keyMap{
 }
 
 type model struct {
-	db     *edb.Db
-	client lit.Library
-	query  string
-	max    int
-	next   *lit.BlobChan
+	db        *edb.Db
+	clients   map[string]lit.Library
+	libraries []lit.Library
+	sources   []lit.Source
+	query     string
+	max       int
+	next      *lit.BlobChan
+
+	// known holds the identifiers of the publications stored so far,
+	// stored how many of them come from a previous session and changed
+	// the libraries whose hit count changed since then.
+	known      map[string]bool
+	stored     int
+	changed    []string
+	duplicates int
+
+	// warnings are issues found while preparing the download.
+	warnings []string
+	quotas   libs.Quotas
 
 	received int
 	err      error
@@ -69,7 +83,7 @@ type model struct {
 }
 
 type blobMsg struct {
-	blob lit.Blob
+	hit lit.Hit
 }
 
 type errMsg struct {
@@ -78,21 +92,21 @@ type errMsg struct {
 
 func handleBlob(blobChan *lit.BlobChan) tea.Cmd {
 	return func() tea.Msg {
-		blob, ok := <-blobChan.Recv()
+		hit, ok := <-blobChan.Recv()
 		if !ok {
 			return errMsg{blobChan.Err()}
 		}
 		return blobMsg{
-			blob: blob,
+			hit: hit,
 		}
 	}
 }
 
-func listenPublications(blobChan *lit.BlobChan, client lit.Library, query string) tea.Cmd {
+func listenPublications(blobChan *lit.BlobChan, sources []lit.Source, query string) tea.Cmd {
 	return func() tea.Msg {
-		lit.GetLiterature(context.Background(), blobChan, client, lit.Request{
+		lit.GetFederatedLiterature(context.Background(), blobChan, lit.Request{
 			Query: query,
-		})
+		}, sources...)
 		return nil
 	}
 }
@@ -100,7 +114,7 @@ func listenPublications(blobChan *lit.BlobChan, client lit.Library, query string
 func (m model) Init() tea.Cmd {
 	return tea.Batch(
 		handleBlob(m.next),
-		listenPublications(m.next, m.client, m.query),
+		listenPublications(m.next, m.sources, m.query),
 	)
 }
 
@@ -115,21 +129,55 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, keys.Quit):
+			if err := m.quotas.Save(m.db, m.libraries); err != nil {
+				m.err = err
+			}
 			return m, tea.Quit
 		}
 	case errMsg:
 		m.done = true
 		m.err = msg.err
+		if err := m.quotas.Save(m.db, m.libraries); err != nil && m.err == nil {
+			m.err = err
+		}
 	case blobMsg:
 		m.received++
-		pub, err := m.client.ParsePublication(msg.blob)
+		client, ok := m.clients[msg.hit.Library]
+		if !ok {
+			m.err = fmt.Errorf("blob from unknown library %q", msg.hit.Library)
+			return m, nil
+		}
+		pub, err := client.ParsePublication(msg.hit.Blob)
 		if err != nil {
 			m.err = err
 			return m, nil
 		}
-		ref := m.client.ToBibTeX(pub)
+		id := lit.PublicationID(client, pub)
+		if m.known[id] {
+			m.duplicates++
+			// The blob is not stored again, but its page is one blob
+			// closer to being complete.
+			if err := m.db.Append(&edb.Event{
+				Id:     fmt.Sprintf("%d", time.Now().UnixNano()),
+				Issuer: client.GetName(),
+				Scope:  "lit",
+				Action: "skip_blob",
+				Data: []string{
+					id,
+					msg.hit.Library,
+					msg.hit.Query,
+					fmt.Sprintf("%d", msg.hit.Page),
+				},
+			}); err != nil {
+				m.err = err
+				return m, nil
+			}
+			return m, handleBlob(m.next)
+		}
+		m.known[id] = true
+		ref := lit.ToBibTeX(client, pub)
 
-		data, err := msg.blob.Marshal()
+		data, err := msg.hit.Blob.Marshal()
 		if err != nil {
 			m.err = err
 			return m, nil
@@ -137,10 +185,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if err := m.db.Append(&edb.Event{
 			Id:     fmt.Sprintf("%d", time.Now().UnixNano()),
-			Issuer: m.client.GetName(),
+			Issuer: client.GetName(),
 			Scope:  "lit",
 			Action: "add_blob",
-			Data:   []string{ref.CiteKey(), data},
+			Data: []string{
+				ref.CiteKey(),
+				data,
+				msg.hit.Library,
+				msg.hit.Query,
+				fmt.Sprintf("%d", msg.hit.Page),
+				id,
+			},
 		}); err != nil {
 			m.err = err
 			return m, nil
@@ -159,14 +214,54 @@ var (
 )
 
 func (m model) View() string {
-	title := fmt.Sprintf("Downloading %q (%d results) from %s...", m.query, m.max, m.client.GetName())
+	// Sources of the same library are year slices of the query.
+	names := []string{}
+	counts := make(map[string]int)
+	slices := make(map[string]int)
+	for _, v := range m.sources {
+		name := v.Library.GetName()
+		if _, ok := counts[name]; !ok {
+			names = append(names, name)
+		}
+		counts[name] += v.Max
+		slices[name]++
+	}
+	for i, v := range names {
+		if slices[v] > 1 {
+			names[i] = fmt.Sprintf("%s (%d, in %d year slices)", v, counts[v], slices[v])
+			continue
+		}
+		names[i] = fmt.Sprintf("%s (%d)", v, counts[v])
+	}
+	title := fmt.Sprintf("Downloading %q (%d results) from %s...", m.query, m.max, strings.Join(names, ", "))
 	titleView := titleStyle.Render(title)
-	progressView := lipgloss.NewStyle().MarginBottom(1).Render(m.progress.ViewAs(float64(m.received) / float64(m.max)))
+	progressView := lipgloss.NewStyle().MarginBottom(1).Render(m.progress.ViewAs(float64(m.stored+m.received) / float64(m.max)))
 	helpView := helpStyle.Render(m.help.View(keys))
 
+	notes := append([]string{}, m.warnings...)
+	notes = append(notes, m.quotas.Notes(m.libraries)...)
+	for _, v := range m.libraries {
+		var s middleware.Measurer
+		if lit.As(v, &s) {
+			notes = append(notes, fmt.Sprintf("%s: %v", v.GetName(), s.Stats()))
+		}
+	}
+	if m.stored > 0 {
+		notes = append(notes, fmt.Sprintf("Resumed: %d results were already stored.", m.stored))
+	}
+	if len(m.changed) > 0 {
+		notes = append(notes, fmt.Sprintf("Hit count changed for %s since last session: downloading again.", strings.Join(m.changed, ", ")))
+	}
+	if m.duplicates > 0 {
+		notes = append(notes, fmt.Sprintf("%d results already stored were skipped.", m.duplicates))
+	}
+	if len(notes) > 0 {
+		progressView += "\n" + strings.Join(notes, "\n") + "\n"
+	}
+
 	var statusView string
 	if m.err != nil {
-		statusView = errorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+		statusView = errorStyle.Render("Error: " + lit.Describe(m.err))
 	} else if m.done {
 		statusView = successStyle.Render("Done!")
 	}
@@ -179,16 +274,120 @@ func (m model) View() string {
 	))
 }
 
-func Program(db *edb.Db, client lit.Library, opts ...tea.ProgramOption) (*tea.Program, error) {
+// session is what previous downloads of a query from a library left in
+// edb: the hit count and page size they were run with, and the
+// publications stored, or skipped as already stored, for each page.
+// Publications are told apart by ID, as pages downloaded more than once
+// store some and skip them afterwards.
+type session struct {
+	max     int
+	perPage int
+	pages   map[int]map[string]bool
+}
+
+func (s *session) add(page int, id string) {
+	if s.pages[page] == nil {
+		s.pages[page] = make(map[string]bool)
+	}
+	s.pages[page][id] = true
+}
+
+type sessionKey struct {
+	query   string
+	library string
+}
+
+// complete returns the pages of s that were fully downloaded along with
+// the number of blobs they hold, provided that the hit count and page size
+// are still max and perPage. Pages are not stable otherwise.
+func (s *session) complete(max, perPage int) (map[int]bool, int) {
+	if s == nil || s.max != max || s.perPage != perPage {
+		return nil, 0
+	}
+	skip := make(map[int]bool)
+	stored := 0
+	for page, ids := range s.pages {
+		n := len(ids)
+		want := max - page*perPage
+		if want > perPage {
+			want = perPage
+		}
+		if n >= want {
+			skip[page] = true
+			stored += n
+		}
+	}
+	return skip, stored
+}
+
+func Program(db *edb.Db, clients []lit.Library, opts ...tea.ProgramOption) (*tea.Program, error) {
+	byName := make(map[string]lit.Library, len(clients))
+	for _, v := range clients {
+		byName[v.GetName()] = v
+	}
+
 	query := ""
+	known := make(map[string]bool)
+	sessions := make(map[sessionKey]*session)
+	quotas := make(libs.Quotas)
 	if err := db.Revive(func(e edb.Event) error {
-		// TODO: we can easility recover from previous download sessions by checking:
-		// 1. wether the max number of items did non change.
-		// 2. if yes, start counting from the last item received.
 		switch e.Action {
 		case "set_query":
 			query = e.Data[0]
-			return nil
+		case "set_quota":
+			return quotas.Revive(e)
+		case "start_download":
+			max, err := strconv.Atoi(e.Data[2])
+			if err != nil {
+				return fmt.Errorf("start_download: max conversion: %w", err)
+			}
+			perPage, err := strconv.Atoi(e.Data[3])
+			if err != nil {
+				return fmt.Errorf("start_download: per page conversion: %w", err)
+			}
+			k := sessionKey{e.Data[0], e.Data[1]}
+			if s, ok := sessions[k]; ok && s.max == max && s.perPage == perPage {
+				return nil
+			}
+			// Results were shuffled, pages stored so far are useless.
+			sessions[k] = &session{max: max, perPage: perPage, pages: make(map[int]map[string]bool)}
+		case "add_blob":
+			// Events stored before blobs had IDs are counted once all
+			// the same.
+			id := e.Id
+			if len(e.Data) >= 6 {
+				id = e.Data[5]
+				known[id] = true
+			} else if client, ok := byName[e.Issuer]; ok {
+				b := new(lit.Blob)
+				if err := b.Unmarshal(e.Data[1]); err != nil {
+					return fmt.Errorf("add_blob: unmarshal: %w", err)
+				}
+				pub, err := client.ParsePublication(*b)
+				if err != nil {
+					return fmt.Errorf("add_blob: parse publication: %w", err)
+				}
+				id = lit.PublicationID(client, pub)
+				known[id] = true
+			}
+			if len(e.Data) < 5 || e.Data[4] == "" {
+				return nil
+			}
+			page, err := strconv.Atoi(e.Data[4])
+			if err != nil {
+				return fmt.Errorf("add_blob: page conversion: %w", err)
+			}
+			if s, ok := sessions[sessionKey{e.Data[3], e.Data[2]}]; ok {
+				s.add(page, id)
+			}
+		case "skip_blob":
+			page, err := strconv.Atoi(e.Data[3])
+			if err != nil {
+				return fmt.Errorf("skip_blob: page conversion: %w", err)
+			}
+			if s, ok := sessions[sessionKey{e.Data[2], e.Data[1]}]; ok {
+				s.add(page, e.Data[0])
+			}
 		default:
 		}
 		return nil
@@ -199,28 +398,102 @@ func Program(db *edb.Db, client lit.Library, opts ...tea.ProgramOption) (*tea.Pr
 		return nil, fmt.Errorf("query not found within edb. Did you run lit-max?")
 	}
 
-	// We might read the max value from edb set_query as well. It might
-	// have changed in the meanwhile though!
-	max, err := client.GetMaxLiterature(context.Background(), lit.Request{
-		Query: query,
-	})
-	if err != nil {
+	// The max values stored by lit-max might have changed in the meanwhile:
+	// ask again, and compare them with the ones of the last session to tell
+	// whether the pages it stored can be trusted.
+	max := 0
+	stored := 0
+	changed := []string{}
+	warnings := []string{}
+	sources := []lit.Source{}
+	for _, v := range clients {
+		n, err := v.GetMaxLiterature(context.Background(), lit.Request{
+			Query: query,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", v.GetName(), err)
+		}
+
+		// Queries exceeding what the library can page through are split
+		// into year slices, each one downloaded on its own.
+		slices := []lit.Slice{{Query: query, Max: n}}
+		var s lit.Slicer
+		if lit.As(v, &s) && n > s.ResultLimit() {
+			slices, err = lit.SliceByYear(context.Background(), v, query, n)
+			var serr *lit.SliceError
+			switch {
+			case errors.As(err, &serr):
+				warnings = append(warnings, fmt.Sprintf("%s: %v, some results will be missing.", v.GetName(), err))
+			case err != nil:
+				return nil, fmt.Errorf("%s: %w", v.GetName(), err)
+			}
+		}
+
+		for _, slice := range slices {
+			name := v.GetName()
+			if len(slices) > 1 {
+				name = fmt.Sprintf("%s %v", v.GetName(), slice)
+			}
+			s := sessions[sessionKey{slice.Query, v.GetName()}]
+			if s != nil && s.max != slice.Max {
+				changed = append(changed, name)
+			}
+			skip, k := s.complete(slice.Max, v.DefaultPerPage())
+			max += slice.Max
+			stored += k
+			sources = append(sources, lit.Source{Library: v, Max: slice.Max, Query: slice.Query, Skip: skip})
+		}
+	}
+
+	// Hit counts were just asked, quotas are up to date.
+	if err := quotas.Save(db, clients); err != nil {
 		return nil, err
 	}
+	if err := lit.CheckQuota(sources...); err != nil {
+		return nil, fmt.Errorf("refusing to start: %w", err)
+	}
+	for _, v := range sources {
+		// Unchanged sessions go on where they were left.
+		s := sessions[sessionKey{v.Query, v.Library.GetName()}]
+		if s != nil && s.max == v.Max && s.perPage == v.Library.DefaultPerPage() {
+			continue
+		}
+		if err := db.Append(&edb.Event{
+			Id:     fmt.Sprintf("%d", time.Now().UnixNano()),
+			Issuer: v.Library.GetName(),
+			Scope:  "lit",
+			Action: "start_download",
+			Data: []string{
+				v.Query,
+				v.Library.GetName(),
+				fmt.Sprintf("%d", v.Max),
+				fmt.Sprintf("%d", v.Library.DefaultPerPage()),
+			},
+		}); err != nil {
+			return nil, err
+		}
+	}
 
 	return tea.NewProgram(model{
-		db:       db,
-		client:   client,
-		query:    query,
-		max:      max,
-		next:     lit.NewBlobChan(max, 0),
-		progress: progress.NewModel(progress.WithDefaultGradient()),
-		help:     help.NewModel(),
+		db:        db,
+		clients:   byName,
+		libraries: clients,
+		sources:   sources,
+		query:     query,
+		max:       max,
+		known:     known,
+		stored:    stored,
+		changed:   changed,
+		warnings:  warnings,
+		quotas:    quotas,
+		next:      lit.NewBlobChan(max, 0),
+		progress:  progress.NewModel(progress.WithDefaultGradient()),
+		help:      help.NewModel(),
 	}, opts...), nil
 }
 
-func Main(db *edb.Db, client lit.Library, opts ...tea.ProgramOption) error {
-	p, err := Program(db, client, opts...)
+func Main(db *edb.Db, clients []lit.Library, opts ...tea.ProgramOption) error {
+	p, err := Program(db, clients, opts...)
 	if err != nil {
 		return err
 	}
@@ -230,13 +503,20 @@ func Main(db *edb.Db, client lit.Library, opts ...tea.ProgramOption) error {
 func main() {
 	flag.Parse()
 
+	clients, err := libs.OpenList(*libName)
+	if err != nil {
+		log.Fatale(err)
+	}
+	if clients, err = libs.Decorate(clients); err != nil {
+		log.Fatale(err)
+	}
+
 	db, err := edb.Open(*edbPath)
 	if err != nil {
 		log.Fatale(err)
 	}
 
-	client := scopus.NewClient(scopusKey)
-	err = Main(db, client, tea.WithoutCatchPanics())
+	err = Main(db, clients, tea.WithoutCatchPanics())
 	db.Close()
 
 	if err != nil {
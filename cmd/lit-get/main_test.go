@@ -8,20 +8,23 @@ import (
 	"io"
 	"math"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/jecoz/edb"
 	"github.com/jecoz/lit"
-	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/littest"
 )
 
 type MockClient struct {
+	name   string
 	maxLit int
 
 	maxLitErr error
 	litErr    error
+	failPages map[int]bool
 
 	requestCount int
 	pubsCount    int
@@ -32,6 +35,9 @@ func (c *MockClient) DefaultPerPage() int {
 }
 
 func (c *MockClient) GetName() string {
+	if c.name != "" {
+		return c.name
+	}
 	return "mock client"
 }
 
@@ -40,6 +46,12 @@ func (c *MockClient) GetRateLimit() time.Duration {
 }
 
 func (c *MockClient) GetLiterature(ctx context.Context, r lit.Request) (lit.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return lit.Response{}, err
+	}
+	if c.failPages[r.Page] {
+		return lit.Response{}, fmt.Errorf("page %d: unavailable", r.Page)
+	}
 	start := r.Page * r.PerPage
 	size := c.maxLit - start
 	if size < 0 {
@@ -62,28 +74,24 @@ func (c *MockClient) GetLiterature(ctx context.Context, r lit.Request) (lit.Resp
 	}, c.litErr
 }
 
-func (c *MockClient) GetMaxLiterature(context.Context, lit.Request) (int, error) {
+func (c *MockClient) GetMaxLiterature(ctx context.Context, r lit.Request) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
 	return c.maxLit, c.maxLitErr
 }
 
-func (c *MockClient) GetAbstract(context.Context, lit.Publication) (lit.Abstract, error) {
-	return lit.Abstract{}, fmt.Errorf("get abstract: not implemented")
-}
-
-func (c *MockClient) ReferenceLink(lit.Publication) string {
-	return "https://nowhere.com"
-}
-
 func (c *MockClient) ParsePublication(b lit.Blob) (lit.Publication, error) {
 	return lit.Publication{
 		Title:     string(b),
-		CoverDate: time.Now(),
+		CoverDate: time.Date(2021, time.May, 10, 0, 0, 0, 0, time.UTC),
 		Creator:   "Ciuck Taylor",
 	}, nil
 }
 
 func (c *MockClient) PrettyPrint(b lit.Blob, dst *bytes.Buffer) error {
-	return fmt.Errorf("not implemented")
+	_, err := dst.Write(b)
+	return err
 }
 
 type MockFile struct {
@@ -115,7 +123,7 @@ func mockDb(q string, maxLit int) (*edb.Db, func()) {
 
 func mockProgram(t *testing.T, db *edb.Db, client lit.Library) (*tea.Program, io.Writer) {
 	inr, inw := io.Pipe()
-	p, err := Program(db, client,
+	p, err := Program(db, []lit.Library{client},
 		tea.WithoutRenderer(),
 		tea.WithoutCatchPanics(),
 		tea.WithInput(inr),
@@ -139,7 +147,7 @@ func TestMainExitWithMaxLitErr(t *testing.T) {
 	defer cleanup()
 
 	t.Run("", func(t *testing.T) {
-		if _, err := Program(db, client,
+		if _, err := Program(db, []lit.Library{client},
 			tea.WithoutRenderer(),
 			tea.WithoutCatchPanics(),
 		); !errors.Is(err, maxLitErr) {
@@ -184,14 +192,8 @@ func (c MockClient) timeout() time.Duration {
 	return time.Millisecond * time.Duration(int(ms)*10)
 }
 
-func (c MockClient) ToBibTeX(p lit.Publication) bibtex.Reference {
-	return bibtex.Misc{
-		Entry: bibtex.Entry{
-			Title:  p.Title,
-			Author: p.Creator,
-			Year:   p.CoverDate.Year(),
-		},
-	}
+func TestMockClientConformance(t *testing.T) {
+	littest.RunConformance(t, &MockClient{maxLit: 60}, littest.Fixture{Query: "some q", Max: 60})
 }
 
 func TestMain(t *testing.T) {
@@ -230,3 +232,265 @@ func TestMain(t *testing.T) {
 		}
 	})
 }
+
+func TestMainFederated(t *testing.T) {
+	t.Parallel()
+	clients := []*MockClient{
+		{name: "first", maxLit: 30},
+		{name: "second", maxLit: 60},
+	}
+	db, cleanup := mockDb("some q", 90)
+	defer cleanup()
+
+	inr, _ := io.Pipe()
+	p, err := Program(db, []lit.Library{clients[0], clients[1]},
+		tea.WithoutRenderer(),
+		tea.WithoutCatchPanics(),
+		tea.WithInput(inr),
+		tea.WithOutput(io.Discard),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		<-time.After(clients[1].timeout())
+		p.Quit()
+	}()
+	i, err := p.StartReturningModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := i.(model); !m.done || m.err != nil {
+		t.Fatalf("program should be done without errors, have done=%v err=%v", m.done, m.err)
+	}
+
+	hits := make(map[string]int)
+	if err := db.Revive(func(e edb.Event) error {
+		if e.Action != "add_blob" {
+			return nil
+		}
+		if len(e.Data) != 6 {
+			return fmt.Errorf("add_blob without provenance: %v", e.Data)
+		}
+		if !strings.HasPrefix(e.Data[5], e.Issuer+":") {
+			return fmt.Errorf("id: have %q, want it prefixed by %q", e.Data[5], e.Issuer)
+		}
+		if e.Data[3] != "some q" {
+			return fmt.Errorf("query: have %q, want %q", e.Data[3], "some q")
+		}
+		if e.Data[2] != e.Issuer {
+			return fmt.Errorf("library: have %q, want %q", e.Data[2], e.Issuer)
+		}
+		hits[e.Data[2]]++
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range clients {
+		if hits[v.name] != v.maxLit {
+			t.Errorf("%s: have %d blobs, want %d", v.name, hits[v.name], v.maxLit)
+		}
+	}
+}
+
+func runProgram(t *testing.T, db *edb.Db, client *MockClient) model {
+	p, _ := mockProgram(t, db, client)
+	go func() {
+		<-time.After(client.timeout())
+		p.Quit()
+	}()
+	i, err := p.StartReturningModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := i.(model)
+	if !m.done {
+		t.Fatalf("program is not done yet")
+	}
+	return m
+}
+
+func countEvents(t *testing.T, db *edb.Db, action string) int {
+	n := 0
+	if err := db.Revive(func(e edb.Event) error {
+		if e.Action == action {
+			n++
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func countBlobs(t *testing.T, db *edb.Db) int {
+	return countEvents(t, db, "add_blob")
+}
+
+func TestMainResume(t *testing.T) {
+	t.Parallel()
+	client := &MockClient{
+		maxLit:    60,
+		failPages: map[int]bool{1: true},
+	}
+	db, cleanup := mockDb("some q", client.maxLit)
+	defer cleanup()
+
+	// The second page fails: the other two are stored.
+	if m := runProgram(t, db, client); m.err == nil {
+		t.Fatalf("expected an error from the second page")
+	}
+	if have, want := countBlobs(t, db), 35; have != want {
+		t.Fatalf("first session: have %d blobs, want %d", have, want)
+	}
+
+	// Only the missing page is downloaded.
+	*client = MockClient{maxLit: 60}
+	m := runProgram(t, db, client)
+	if m.err != nil {
+		t.Fatal(m.err)
+	}
+	if client.requestCount != 1 {
+		t.Fatalf("second session: have %d requests, want 1", client.requestCount)
+	}
+	if m.stored != 35 {
+		t.Fatalf("second session: have %d stored results, want 35", m.stored)
+	}
+	if have, want := countBlobs(t, db), 60; have != want {
+		t.Fatalf("second session: have %d blobs, want %d", have, want)
+	}
+
+	// Nothing left to do.
+	*client = MockClient{maxLit: 60}
+	if m := runProgram(t, db, client); m.err != nil || client.requestCount != 0 {
+		t.Fatalf("third session: have %d requests, error %v", client.requestCount, m.err)
+	}
+
+	// The hit count changed: everything is downloaded again, without
+	// storing duplicates.
+	*client = MockClient{maxLit: 61}
+	m = runProgram(t, db, client)
+	if m.err != nil {
+		t.Fatal(m.err)
+	}
+	if len(m.changed) != 1 || client.requestCount != 3 || m.duplicates != 60 {
+		t.Fatalf("fourth session: have changed=%v requests=%d duplicates=%d", m.changed, client.requestCount, m.duplicates)
+	}
+	if have, want := countBlobs(t, db), 61; have != want {
+		t.Fatalf("fourth session: have %d blobs, want %d", have, want)
+	}
+
+	// Pages made of duplicates are complete as well.
+	*client = MockClient{maxLit: 61}
+	if m := runProgram(t, db, client); m.err != nil || client.requestCount != 0 || m.stored != 61 {
+		t.Fatalf("fifth session: have %d requests, %d stored results, error %v", client.requestCount, m.stored, m.err)
+	}
+	// Sessions are only started by the first run and the hit count
+	// change.
+	if have, want := countEvents(t, db, "start_download"), 2; have != want {
+		t.Fatalf("have %d sessions started, want %d", have, want)
+	}
+}
+
+// appendEvent appends an event of lit-get issued by client to db.
+func appendEvent(t *testing.T, db *edb.Db, client lit.Library, action string, data ...string) {
+	if err := db.Append(&edb.Event{
+		Id:     fmt.Sprintf("%d", time.Now().UnixNano()),
+		Issuer: client.GetName(),
+		Scope:  "lit",
+		Action: action,
+		Data:   data,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMainResumeInterruptedTwice(t *testing.T) {
+	t.Parallel()
+	client := &MockClient{maxLit: 25}
+	db, cleanup := mockDb("some q", client.maxLit)
+	defer cleanup()
+
+	blob := func(i int) (string, string) {
+		b := lit.Blob(fmt.Sprintf("pub #%d", i))
+		p, err := client.ParsePublication(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := b.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data, lit.PublicationID(client, p)
+	}
+	name := client.GetName()
+	appendEvent(t, db, client, "start_download", "some q", name, "25", "25")
+	// The first session stores 10 blobs of the page, then dies.
+	for i := 0; i < 10; i++ {
+		data, id := blob(i)
+		appendEvent(t, db, client, "add_blob", "key", data, name, "some q", "0", id)
+	}
+	// The second one skips them, stores 6 more and dies too.
+	for i := 0; i < 16; i++ {
+		data, id := blob(i)
+		if i < 10 {
+			appendEvent(t, db, client, "skip_blob", id, name, "some q", "0")
+			continue
+		}
+		appendEvent(t, db, client, "add_blob", "key", data, name, "some q", "0", id)
+	}
+
+	m := runProgram(t, db, client)
+	if m.err != nil || client.requestCount != 1 || m.stored != 0 || m.duplicates != 16 {
+		t.Fatalf("have %d requests, %d stored, %d duplicates, error %v", client.requestCount, m.stored, m.duplicates, m.err)
+	}
+	if have, want := countBlobs(t, db), 25; have != want {
+		t.Fatalf("have %d blobs, want %d", have, want)
+	}
+}
+
+type QuotaClient struct {
+	*MockClient
+
+	quota lit.Quota
+}
+
+func (c *QuotaClient) Quota() (lit.Quota, bool) {
+	return c.quota, true
+}
+
+func TestMainRefusesOverQuota(t *testing.T) {
+	t.Parallel()
+	client := &QuotaClient{
+		MockClient: &MockClient{maxLit: 60},
+		quota:      lit.Quota{Limit: 100, Remaining: 2, Time: time.Now()},
+	}
+	db, cleanup := mockDb("some q", client.maxLit)
+	defer cleanup()
+
+	_, err := Program(db, []lit.Library{client})
+	if !errors.Is(err, lit.ErrQuotaExceeded) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	actions := make(map[string]int)
+	if err := db.Revive(func(e edb.Event) error {
+		actions[e.Action]++
+		if e.Action == "set_quota" && e.Data[2] != "2" {
+			t.Errorf("unexpected quota stored: %v", e.Data)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if actions["set_quota"] != 1 || actions["start_download"] != 0 {
+		t.Fatalf("unexpected events: %v", actions)
+	}
+	if client.requestCount != 0 {
+		t.Fatalf("have %d requests, want none", client.requestCount)
+	}
+
+	client.quota.Remaining = 3
+	if _, err := Program(db, []lit.Library{client}); err != nil {
+		t.Fatal(err)
+	}
+}
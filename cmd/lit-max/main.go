@@ -4,8 +4,8 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -15,8 +15,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jecoz/edb"
 	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/libs"
 	"github.com/jecoz/lit/log"
-	"github.com/jecoz/lit/scopus"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
@@ -24,12 +25,9 @@ const (
 	Margin   = 1
 )
 
-var (
-	scopusKey = os.Getenv("SCOPUS_API_KEY")
-)
-
 var (
 	edbPath = flag.String("edb", "lit.edb", "Event database file. Everything will be stored here.")
+	libName = flag.String("lib", libs.Default, libs.Usage())
 )
 
 type keyMap struct {
@@ -67,36 +65,80 @@ func (m errMsg) Error() string {
 	return m.err.Error()
 }
 
+func (m errMsg) Unwrap() error {
+	return m.err
+}
+
 type maxMsg struct {
-	max   int
 	query string
+
+	// maxes holds the number of results of each library.
+	maxes []int
+	// years breaks them down by year of publication, for the
+	// libraries that are lit.FacetProvider.
+	years [][]lit.Facet
+}
+
+func (m maxMsg) total() int {
+	total := 0
+	for _, v := range m.maxes {
+		total += v
+	}
+	return total
 }
 
-func getMaxLiterature(client lit.Library, q string) tea.Cmd {
+func getMaxLiterature(clients []lit.Library, q string) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 		defer cancel()
 
-		max, err := client.GetMaxLiterature(ctx, lit.Request{
-			Query: q,
-		})
-		if err != nil {
+		maxes := make([]int, len(clients))
+		years := make([][]lit.Facet, len(clients))
+		g, ctx := errgroup.WithContext(ctx)
+		for i, v := range clients {
+			i, client := i, v
+			g.Go(func() error {
+				max, err := client.GetMaxLiterature(ctx, lit.Request{
+					Query: q,
+				})
+				if err != nil {
+					return fmt.Errorf("%s: %w", client.GetName(), err)
+				}
+				maxes[i] = max
+
+				var fp lit.FacetProvider
+				if !lit.As(client, &fp) {
+					return nil
+				}
+				// The breakdown is a nicety, the query stands
+				// without it.
+				if facets, err := fp.Facets(ctx, lit.Request{Query: q}, lit.FacetYear); err == nil {
+					years[i] = facets
+				}
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
 			return errMsg{err}
 		}
 		return maxMsg{
-			max:   max,
 			query: q,
+			maxes: maxes,
+			years: years,
 		}
 	}
 }
 
 type model struct {
-	db     *edb.Db
-	client lit.Library
+	db      *edb.Db
+	clients []lit.Library
 
 	searching bool
 	query     string
 	max       int
+	maxes     []int
+	years     [][]lit.Facet
+	quotas    libs.Quotas
 	err       error
 
 	help      help.Model
@@ -118,25 +160,40 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, keys.Search):
 			m.searching = true
 			m.err = nil
-			return m, getMaxLiterature(m.client, m.textInput.Value())
+			return m, getMaxLiterature(m.clients, m.textInput.Value())
 		}
 	case errMsg:
 		m.searching = false
 		m.err = msg
+		if err := m.quotas.Save(m.db, m.clients); err != nil {
+			m.err = err
+		}
 		return m, nil
 	case maxMsg:
+		// The total is followed by the hits of each library, as
+		// required for PRISMA reporting.
+		data := []string{msg.query, fmt.Sprintf("%d", msg.total())}
+		for i, v := range m.clients {
+			data = append(data, v.GetName(), fmt.Sprintf("%d", msg.maxes[i]))
+		}
 		if err := m.db.Append(&edb.Event{
 			Id:     fmt.Sprintf("%d", time.Now().UnixNano()),
 			Issuer: "reviewer", // TODO: reviewer id?
 			Scope:  "lit",
 			Action: "set_query",
-			Data:   []string{msg.query, fmt.Sprintf("%d", msg.max)},
+			Data:   data,
 		}); err != nil {
 			m.err = err
 			return m, nil
 		}
+		if err := m.quotas.Save(m.db, m.clients); err != nil {
+			m.err = err
+			return m, nil
+		}
 		m.searching = false
-		m.max = msg.max
+		m.max = msg.total()
+		m.maxes = msg.maxes
+		m.years = msg.years
 		m.query = msg.query
 		return m, nil
 	}
@@ -160,12 +217,41 @@ var (
 
 func (m model) queryView() string {
 	if m.err != nil {
-		return errorStyle.Render(fmt.Sprintf("error: %v", m.err))
+		return errorStyle.Render("error: " + lit.Describe(m.err))
 	}
 	if m.searching {
 		return searchingStyle.Render("searching...")
 	}
-	return resultStyle.Render(fmt.Sprintf("%q hit %d results", m.query, m.max))
+	view := fmt.Sprintf("%q hit %d results", m.query, m.max)
+	if len(m.clients) > 1 && len(m.maxes) == len(m.clients) {
+		hits := make([]string, len(m.clients))
+		for i, v := range m.clients {
+			hits[i] = fmt.Sprintf("%s: %d", v.GetName(), m.maxes[i])
+		}
+		view += fmt.Sprintf(" (%s)", strings.Join(hits, ", "))
+	}
+	for i, v := range m.years {
+		if len(v) == 0 {
+			continue
+		}
+		years := make([]string, len(v))
+		for j, f := range v {
+			years[j] = fmt.Sprintf("%s: %d", f.Value, f.Count)
+		}
+		view += fmt.Sprintf("\n%s by year: %s", m.clients[i].GetName(), strings.Join(years, ", "))
+	}
+	notes := []string{}
+	for i, v := range m.clients {
+		var w lit.Windowed
+		if i < len(m.maxes) && lit.As(v, &w) && m.maxes[i] >= w.Window() {
+			notes = append(notes, fmt.Sprintf("%s only reaches the first %d results, refine the query to get them all.", v.GetName(), w.Window()))
+		}
+	}
+	notes = append(notes, m.quotas.Notes(m.clients)...)
+	if len(notes) > 0 {
+		view += "\n" + strings.Join(notes, "\n")
+	}
+	return resultStyle.Render(view)
 }
 
 func (m model) View() string {
@@ -180,6 +266,14 @@ func (m model) View() string {
 }
 
 func Main() error {
+	clients, err := libs.OpenList(*libName)
+	if err != nil {
+		return err
+	}
+	if clients, err = libs.Decorate(clients); err != nil {
+		return err
+	}
+
 	db, err := edb.Open(*edbPath)
 	if err != nil {
 		return err
@@ -188,8 +282,12 @@ func Main() error {
 
 	query := ""
 	max := 0
+	var maxes []int
+	quotas := make(libs.Quotas)
 	if err := db.Revive(func(e edb.Event) error {
 		switch e.Action {
+		case "set_quota":
+			return quotas.Revive(e)
 		case "set_query":
 			var err error
 			query = e.Data[0]
@@ -197,6 +295,23 @@ func Main() error {
 			if err != nil {
 				return fmt.Errorf("parse maximum literature count: %w", err)
 			}
+			maxes = nil
+			byName := make(map[string]int)
+			for i := 2; i+1 < len(e.Data); i += 2 {
+				n, err := strconv.Atoi(e.Data[i+1])
+				if err != nil {
+					return fmt.Errorf("parse %s literature count: %w", e.Data[i], err)
+				}
+				byName[e.Data[i]] = n
+			}
+			for _, v := range clients {
+				n, ok := byName[v.GetName()]
+				if !ok {
+					maxes = nil
+					break
+				}
+				maxes = append(maxes, n)
+			}
 		default:
 			return nil
 		}
@@ -212,10 +327,12 @@ func Main() error {
 
 	return tea.NewProgram(model{
 		db:        db,
-		client:    scopus.NewClient(scopusKey),
+		clients:   clients,
 		textInput: ti,
 		query:     query,
 		max:       max,
+		maxes:     maxes,
+		quotas:    quotas,
 		help:      help.NewModel(),
 	}).Start()
 }
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jecoz/edb"
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/libs"
+	"github.com/jecoz/lit/log"
+)
+
+var (
+	edbPath = flag.String("edb", "lit.edb", "Event database file to migrate. The original is kept with a .bak suffix.")
+	libName = flag.String("lib", libs.Default, libs.Usage())
+)
+
+// Stats tells how many events Migrate rewrote.
+type Stats struct {
+	Events    int
+	Rewritten int
+	// Mismatches counts the events whose cite key does not match the one
+	// of the publication at their index: they are migrated following the
+	// index, as previous versions did.
+	Mismatches int
+}
+
+// blobLibrary returns the name of the library an add_blob event comes
+// from. Older events do not carry provenance, the issuer is used instead.
+func blobLibrary(e edb.Event) string {
+	if len(e.Data) >= 5 {
+		return e.Data[2]
+	}
+	return e.Issuer
+}
+
+// Migrate writes the events of db to w, rewriting the ones referring to
+// publications by cite key and position so that they use the stable
+// identifiers returned by lit.PublicationID. add_blob events are completed
+// with provenance and identifier, merge_blobs events referring to add_blob
+// event ids are rewritten too. Events already migrated are left alone,
+// making Migrate safe to run more than once.
+func Migrate(db *edb.Db, clients []lit.Library, w io.Writer) (Stats, error) {
+	byName := make(map[string]lit.Library, len(clients))
+	for _, v := range clients {
+		byName[v.GetName()] = v
+	}
+
+	var (
+		stats  Stats
+		query  string
+		pubs   []lit.Publication
+		ids    []string
+		owners []lit.Library
+		blobs  = make(map[string]string)
+	)
+	cw := csv.NewWriter(w)
+	lookup := func(i string) (int, error) {
+		n, err := strconv.Atoi(i)
+		if err != nil {
+			return 0, fmt.Errorf("index conversion: %w", err)
+		}
+		if n < 0 || n >= len(ids) {
+			return 0, fmt.Errorf("index %d out of range, %d publications found", n, len(ids))
+		}
+		return n, nil
+	}
+	if err := db.Revive(func(e edb.Event) error {
+		stats.Events++
+		data := append([]string(nil), e.Data...)
+		rewritten := false
+
+		switch e.Action {
+		case "set_query":
+			query = data[0]
+		case "add_blob":
+			client, ok := byName[blobLibrary(e)]
+			if !ok {
+				return fmt.Errorf("add_blob: library %q not selected, see -lib", blobLibrary(e))
+			}
+			b := new(lit.Blob)
+			if err := b.Unmarshal(data[1]); err != nil {
+				return fmt.Errorf("add_blob: unmarshal: %w", err)
+			}
+			pub, err := client.ParsePublication(*b)
+			if err != nil {
+				return fmt.Errorf("add_blob: parse publication: %w", err)
+			}
+			id := lit.PublicationID(client, pub)
+			if len(data) < 5 {
+				// The page is unknown.
+				data = []string{data[0], data[1], client.GetName(), query, ""}
+				rewritten = true
+			}
+			if len(data) < 6 {
+				data = append(data, id)
+				rewritten = true
+			}
+			id = data[5]
+			pubs = append(pubs, pub)
+			ids = append(ids, id)
+			owners = append(owners, client)
+			blobs[e.Id] = id
+		case "add_abstract", "add_review", "add_keywords":
+			if len(data) != 3 {
+				break
+			}
+			i, err := lookup(data[2])
+			if err != nil {
+				return fmt.Errorf("%s: %w", e.Action, err)
+			}
+			if key := lit.ToBibTeX(owners[i], pubs[i]).CiteKey(); key != data[0] {
+				log.Warn("%s %s: cite key %q does not match %q at index %d", e.Action, e.Id, data[0], key, i)
+				stats.Mismatches++
+			}
+			data = []string{ids[i], data[1]}
+			rewritten = true
+		case "move_cursor":
+			if _, err := strconv.Atoi(data[0]); err != nil {
+				break
+			}
+			i, err := lookup(data[0])
+			if err != nil {
+				return fmt.Errorf("%s: %w", e.Action, err)
+			}
+			data = []string{ids[i]}
+			rewritten = true
+		case "merge_blobs":
+			kept, ok := blobs[data[0]]
+			if !ok {
+				break
+			}
+			dup, ok := blobs[data[1]]
+			if !ok {
+				break
+			}
+			data[0], data[1] = kept, dup
+			rewritten = true
+		default:
+		}
+
+		if rewritten {
+			stats.Rewritten++
+		}
+		return cw.Write(append([]string{
+			e.Id,
+			e.Issuer,
+			e.Scope,
+			e.Action,
+			e.Time.Format(time.RFC3339),
+		}, data...))
+	}); err != nil {
+		return stats, err
+	}
+	cw.Flush()
+	return stats, cw.Error()
+}
+
+func Main() error {
+	clients, err := libs.OpenList(*libName)
+	if err != nil {
+		return err
+	}
+
+	backup := *edbPath + ".bak"
+	if _, err := os.Stat(backup); err == nil {
+		return fmt.Errorf("%s already exists, remove it to migrate again", backup)
+	}
+
+	db, err := edb.Open(*edbPath)
+	if err != nil {
+		return err
+	}
+	tmp := *edbPath + ".migrating"
+	f, err := os.Create(tmp)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	stats, err := Migrate(db, clients, f)
+	db.Close()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(*edbPath, backup); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, *edbPath); err != nil {
+		return err
+	}
+	log.Info("%s: %d events, %d rewritten, %d cite key mismatches. Original saved as %s",
+		*edbPath, stats.Events, stats.Rewritten, stats.Mismatches, backup)
+	return nil
+}
+
+func main() {
+	flag.Parse()
+
+	if err := Main(); err != nil {
+		log.Fatale(err)
+	}
+}
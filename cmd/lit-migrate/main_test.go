@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jecoz/edb"
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+)
+
+type MockClient struct {
+	lit.Library
+}
+
+func (c MockClient) GetName() string {
+	return "mock client"
+}
+
+func (c MockClient) ParsePublication(b lit.Blob) (lit.Publication, error) {
+	return lit.Publication{
+		Title:     string(b),
+		CoverDate: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Creator:   "Ciuck Taylor",
+		Values:    map[string]string{lit.KeyDOI: "10.1/" + string(b)},
+	}, nil
+}
+
+func (c MockClient) ToBibTeX(p lit.Publication) bibtex.Reference {
+	return bibtex.Misc{
+		Entry: bibtex.Entry{
+			Title:  p.Title,
+			Author: p.Creator,
+			Year:   p.CoverDate.Year(),
+		},
+	}
+}
+
+func mockDb(t *testing.T, contents []byte) *edb.Db {
+	f, err := os.CreateTemp("", "lit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		f.Close()
+		os.Remove(f.Name())
+	})
+	if _, err := f.Write(contents); err != nil {
+		t.Fatal(err)
+	}
+	return edb.New(f)
+}
+
+func appendEvents(t *testing.T, db *edb.Db, events ...edb.Event) {
+	for i, v := range events {
+		v := v
+		v.Id = fmt.Sprintf("%d", i)
+		v.Scope = "lit"
+		if err := db.Append(&v); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func blob(s string) string {
+	data, err := lit.Blob(s).Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func revive(t *testing.T, db *edb.Db) []edb.Event {
+	events := []edb.Event{}
+	if err := db.Revive(func(e edb.Event) error {
+		e.Data = append([]string(nil), e.Data...)
+		events = append(events, e)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestMigrate(t *testing.T) {
+	client := MockClient{}
+	key := func(title string) string {
+		pub, _ := client.ParsePublication(lit.Blob(title))
+		return client.ToBibTeX(pub).CiteKey()
+	}
+	id := func(title string) string {
+		pub, _ := client.ParsePublication(lit.Blob(title))
+		return lit.PublicationID(client, pub)
+	}
+
+	db := mockDb(t, nil)
+	appendEvents(t, db,
+		edb.Event{Issuer: "testing", Action: "set_query", Data: []string{"some q", "2"}},
+		edb.Event{Issuer: "mock client", Action: "add_blob", Data: []string{key("first"), blob("first")}},
+		edb.Event{Issuer: "mock client", Action: "add_blob", Data: []string{key("second"), blob("second"), "mock client", "some q", "1"}},
+		edb.Event{Issuer: "reviewer", Action: "add_review", Data: []string{key("second"), "review", "1"}},
+		edb.Event{Issuer: "reviewer", Action: "add_keywords", Data: []string{"Wrong2020Key", "keywords", "0"}},
+		edb.Event{Issuer: "dedupe", Action: "merge_blobs", Data: []string{"2", "1", "doi", "1.00"}},
+		edb.Event{Issuer: "reviewer", Action: "move_cursor", Data: []string{"1"}},
+	)
+
+	var buf bytes.Buffer
+	stats, err := Migrate(db, []lit.Library{client}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Events != 7 || stats.Rewritten != 6 || stats.Mismatches != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	migrated := mockDb(t, buf.Bytes())
+	events := revive(t, migrated)
+
+	want := [][]string{
+		{"some q", "2"},
+		{key("first"), blob("first"), "mock client", "some q", "", id("first")},
+		{key("second"), blob("second"), "mock client", "some q", "1", id("second")},
+		{id("second"), "review"},
+		{id("first"), "keywords"},
+		{id("second"), id("first"), "doi", "1.00"},
+		{id("second")},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("have %d events, want %d", len(events), len(want))
+	}
+	for i, v := range events {
+		if fmt.Sprint(v.Data) != fmt.Sprint(want[i]) {
+			t.Errorf("%s: have %q, want %q", v.Action, v.Data, want[i])
+		}
+	}
+
+	// Migrating twice changes nothing.
+	var again bytes.Buffer
+	stats, err = Migrate(migrated, []lit.Library{client}, &again)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Rewritten != 0 {
+		t.Errorf("second migration rewrote %d events", stats.Rewritten)
+	}
+	if again.String() != buf.String() {
+		t.Errorf("second migration changed the file:\n%s\n%s", buf.String(), again.String())
+	}
+}
+
+func TestMigrateOtherLibrary(t *testing.T) {
+	// A legacy edb downloaded from Scopus, migrated with another library
+	// selected.
+	db := mockDb(t, nil)
+	appendEvents(t, db,
+		edb.Event{Issuer: "testing", Action: "set_query", Data: []string{"some q", "1"}},
+		edb.Event{Issuer: "Scopus", Action: "add_blob", Data: []string{"Key", blob("first")}},
+	)
+	var buf bytes.Buffer
+	if _, err := Migrate(db, []lit.Library{MockClient{}}, &buf); err == nil || !strings.Contains(err.Error(), `library "Scopus" not selected`) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
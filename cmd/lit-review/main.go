@@ -4,9 +4,11 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -20,16 +22,15 @@ import (
 	"github.com/jecoz/edb"
 	"github.com/jecoz/lit"
 	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/dedupe"
+	"github.com/jecoz/lit/libs"
 	"github.com/jecoz/lit/log"
-	"github.com/jecoz/lit/scopus"
-)
-
-var (
-	scopusKey = os.Getenv("SCOPUS_API_KEY")
 )
 
 var (
 	edbPath = flag.String("edb", "lit.edb", "Event database file. Everything will be stored here.")
+	libName = flag.String("lib", libs.Default, libs.Usage())
+	minSim  = flag.Float64("dedupe", dedupe.DefaultThreshold, "Title similarity (0-1) above which publications are merged as duplicates. Use a value above 1 to merge by DOI only.")
 )
 
 const (
@@ -141,9 +142,12 @@ func newNormalMode() normalMode {
 }
 
 type model struct {
-	db     *edb.Db
-	query  string
-	client lit.Library
+	db    *edb.Db
+	query string
+
+	// clients are the libraries selected, quotas their latest quota.
+	clients []lit.Library
+	quotas  libs.Quotas
 
 	normal normalMode
 	insert insertMode
@@ -152,6 +156,15 @@ type model struct {
 	cursor int
 	pubs   []lit.Publication
 
+	// sources holds the library each publication comes from, ids their
+	// stable identifiers, see lit.PublicationID.
+	sources []lit.Library
+	ids     []string
+
+	// duplicates maps the index of each publication merged into another
+	// one to the index of the publication kept. Duplicates are skipped.
+	duplicates map[int]int
+
 	rejecting     bool
 	printing      bool
 	inspecting    bool
@@ -167,7 +180,7 @@ type model struct {
 }
 
 func (m model) Init() tea.Cmd {
-	return getAbstract(m.client, m.cursor, m.pubs[m.cursor])
+	return getAbstract(m.sources[m.cursor], m.cursor, m.pubs[m.cursor])
 }
 
 type cursorMsg int
@@ -219,30 +232,25 @@ type inspectMsg struct {
 	dump string
 }
 
-func makeInspection(client lit.Library, db *edb.Db, pub lit.Publication) tea.Cmd {
+func makeInspection(client lit.Library, db *edb.Db, id string) tea.Cmd {
 	return func() tea.Msg {
-		var (
-			event edb.Event
-			ok    bool
-		)
-		key := client.ToBibTeX(pub).CiteKey()
+		var blob *lit.Blob
 		db.Revive(func(e edb.Event) error {
-			if e.Scope == "lit" && e.Action == "add_blob" && e.Data[0] == key {
-				ok = true
-				event = e
-				return fmt.Errorf("stop")
+			if e.Scope != "lit" || e.Action != "add_blob" || blobLibrary(e) != client.GetName() {
+				return nil
 			}
-			return nil
+			b, have, err := blobID(client, e)
+			if err != nil || have != id {
+				return nil
+			}
+			blob = b
+			return fmt.Errorf("stop")
 		})
-		if !ok {
+		if blob == nil {
 			return errMsg{
-				fmt.Errorf("make inspection: blob %q not found", key),
+				fmt.Errorf("make inspection: blob %q not found", id),
 			}
 		}
-		blob := new(lit.Blob)
-		if err := blob.Unmarshal(event.Data[1]); err != nil {
-			return errMsg{err}
-		}
 		var buf bytes.Buffer
 		if err := client.PrettyPrint(*blob, &buf); err != nil {
 			return errMsg{err}
@@ -253,16 +261,16 @@ func makeInspection(client lit.Library, db *edb.Db, pub lit.Publication) tea.Cmd
 	}
 }
 
-func saveReview(client lit.Library, name string, pubs []lit.Publication) tea.Cmd {
+func saveReview(sources []lit.Library, name string, pubs []lit.Publication) tea.Cmd {
 	return func() tea.Msg {
 		accepted := make([]bibtex.Reference, 0, len(pubs))
 		rejected := make([]bibtex.Reference, 0, len(pubs))
-		for _, v := range pubs {
+		for i, v := range pubs {
 			if v.Review != nil && v.Review.IsAccepted {
-				accepted = append(accepted, client.ToBibTeX(v))
+				accepted = append(accepted, lit.ToBibTeX(sources[i], v))
 			}
 			if v.Review != nil && !v.Review.IsAccepted {
-				rejected = append(rejected, client.ToBibTeX(v))
+				rejected = append(rejected, lit.ToBibTeX(sources[i], v))
 			}
 		}
 
@@ -303,6 +311,10 @@ func (m errMsg) Error() string {
 	return m.err.Error()
 }
 
+func (m errMsg) Unwrap() error {
+	return m.err
+}
+
 type abstractMsg struct {
 	cursor int
 	pub    lit.Publication
@@ -310,7 +322,8 @@ type abstractMsg struct {
 
 func getAbstract(client lit.Library, cursor int, p lit.Publication) tea.Cmd {
 	return func() tea.Msg {
-		if p.Abstract != nil {
+		var abstracts lit.AbstractProvider
+		if p.Abstract != nil || !lit.As(client, &abstracts) {
 			return nil
 		}
 
@@ -363,7 +376,7 @@ func (m model) handleKeyNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.printing = true
 		return m, nil
 	case key.Matches(msg, keys.Inspect):
-		return m, makeInspection(m.client, m.db, m.pubs[m.cursor])
+		return m, makeInspection(m.sources[m.cursor], m.db, m.ids[m.cursor])
 	case key.Matches(msg, keys.Label):
 		m.textInput.Focus()
 		m.textInput.Placeholder = PlaceholderLabel
@@ -405,7 +418,7 @@ func (m model) handleKeyInsert(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				moveCursor(m.cursor+1),
 			)
 		case m.printing:
-			cmd = saveReview(m.client, m.textInput.Value(), m.pubs)
+			cmd = saveReview(m.sources, m.textInput.Value(), m.pubs)
 		case m.labeling:
 			cmd = makeKeywords(m.cursor, m.pubs[m.cursor], m.textInput.Value())
 		}
@@ -438,6 +451,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKey(msg)
 	case errMsg:
 		m.err = msg
+		if err := m.quotas.Save(m.db, m.clients); err != nil {
+			m.err = err
+		}
 		return m, nil
 	case abstractMsg:
 		data, err := msg.pub.Abstract.Marshal()
@@ -445,17 +461,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = err
 			return m, nil
 		}
-		ref := m.client.ToBibTeX(msg.pub)
 		if err := m.db.Append(&edb.Event{
 			Id:     fmt.Sprintf("%d", time.Now().UnixNano()),
 			Issuer: "reviewer",
 			Scope:  "lit",
 			Action: "add_abstract",
-			Data:   []string{ref.CiteKey(), data, fmt.Sprintf("%d", msg.cursor)},
+			Data:   []string{m.ids[msg.cursor], data},
 		}); err != nil {
 			m.err = err
 			return m, nil
 		}
+		if err := m.quotas.Save(m.db, m.clients); err != nil {
+			m.err = err
+			return m, nil
+		}
 		m.pubs[msg.cursor] = msg.pub
 		return m, nil
 	case reviewMsg:
@@ -464,13 +483,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = err
 			return m, nil
 		}
-		ref := m.client.ToBibTeX(msg.pub)
 		if err := m.db.Append(&edb.Event{
 			Id:     fmt.Sprintf("%d", time.Now().UnixNano()),
 			Issuer: "reviewer",
 			Scope:  "lit",
 			Action: "add_review",
-			Data:   []string{ref.CiteKey(), data, fmt.Sprintf("%d", msg.cursor)},
+			Data:   []string{m.ids[msg.cursor], data},
 		}); err != nil {
 			m.err = err
 			return m, nil
@@ -490,13 +508,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = err
 			return m, nil
 		}
-		ref := m.client.ToBibTeX(msg.pub)
 		if err := m.db.Append(&edb.Event{
 			Id:     fmt.Sprintf("%d", time.Now().UnixNano()),
 			Issuer: "reviewer",
 			Scope:  "lit",
 			Action: "add_keywords",
-			Data:   []string{ref.CiteKey(), data, fmt.Sprintf("%d", msg.cursor)},
+			Data:   []string{m.ids[msg.cursor], data},
 		}); err != nil {
 			m.err = err
 			return m, nil
@@ -509,15 +526,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case cursorMsg:
 		cursor := int(msg)
-		switch {
-		case cursor < 0:
-			cursor = len(m.pubs) + cursor
-		case cursor > len(m.pubs)-1:
-			cursor = cursor - len(m.pubs)
+		step := 1
+		if cursor < m.cursor {
+			step = -1
+		}
+		for {
+			switch {
+			case cursor < 0:
+				cursor = len(m.pubs) + cursor
+			case cursor > len(m.pubs)-1:
+				cursor = cursor - len(m.pubs)
+			}
+			if _, ok := m.duplicates[cursor]; !ok {
+				break
+			}
+			cursor += step
 		}
 		m.cursor = cursor
 		m.err = nil
-		return m, getAbstract(m.client, m.cursor, m.pubs[m.cursor])
+		return m, getAbstract(m.sources[m.cursor], m.cursor, m.pubs[m.cursor])
 	case quitMsg:
 		// NOTE: if an error occurs here we won't catch it.
 		m.db.Append(&edb.Event{
@@ -525,7 +552,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			Issuer: "reviewer",
 			Scope:  "lit",
 			Action: "move_cursor",
-			Data:   []string{fmt.Sprintf("%d", int(m.cursor))},
+			Data:   []string{m.ids[m.cursor]},
 		})
 		return m, tea.Quit
 	}
@@ -565,14 +592,18 @@ func (m model) titleView() string {
 
 func (m model) creatorView() string {
 	p := m.pubs[m.cursor]
-	ref := m.client.ToBibTeX(p)
-	return m.style.abstract.Render(fmt.Sprintf("%s, %d (%s, %s)", p.Creator, p.CoverDate.Year(), ref.CiteKey(), ref.EntryType()))
+	client := m.sources[m.cursor]
+	ref := lit.ToBibTeX(client, p)
+	return m.style.abstract.Render(fmt.Sprintf("%s, %d (%s, %s, %s)", p.Creator, p.CoverDate.Year(), ref.CiteKey(), ref.EntryType(), client.GetName()))
 }
 
 func (m model) linkView() string {
 	p := m.pubs[m.cursor]
-	l := m.client.ReferenceLink(p)
-	return m.style.link.Render(l)
+	var links lit.FullTextProvider
+	if !lit.As(m.sources[m.cursor], &links) {
+		return ""
+	}
+	return m.style.link.Render(links.ReferenceLink(p))
 }
 
 func (m model) statusView() string {
@@ -607,16 +638,25 @@ func (m model) statusView() string {
 	return strings.Join(fields, "\n")
 }
 
+// total returns the number of publications to review, duplicates excluded.
+func (m model) total() int {
+	return len(m.pubs) - len(m.duplicates)
+}
+
 func (m model) progressView() string {
-	return m.progress.ViewAs(float64(m.acceptedCount+m.rejectedCount) / float64(len(m.pubs)))
+	return m.progress.ViewAs(float64(m.acceptedCount+m.rejectedCount) / float64(m.total()))
 }
 
 func (m model) abstractView() string {
 	p := m.pubs[m.cursor]
 	abstractView := m.style.todo.Render("downloading abstract...")
+	var abstracts lit.AbstractProvider
+	if !lit.As(m.sources[m.cursor], &abstracts) {
+		abstractView = m.style.todo.Render("no abstract available from " + m.sources[m.cursor].GetName())
+	}
 	switch {
 	case m.err != nil:
-		abstractView = m.style.err.Render(fmt.Sprintf("error: %v", m.err))
+		abstractView = m.style.err.Render("error: " + lit.Describe(m.err))
 	case p.Abstract != nil:
 		abstractView = m.style.abstract.Render(p.Abstract.GetText())
 	}
@@ -624,12 +664,17 @@ func (m model) abstractView() string {
 }
 
 func (m model) statsView() string {
-	return m.style.abstract.Render(fmt.Sprintf("[total=%d todo=%d accepted=%d rejected=%d]",
-		len(m.pubs),
-		len(m.pubs)-(m.acceptedCount+m.rejectedCount),
+	stats := fmt.Sprintf("[total=%d todo=%d accepted=%d rejected=%d duplicates=%d]",
+		m.total(),
+		m.total()-(m.acceptedCount+m.rejectedCount),
 		m.acceptedCount,
 		m.rejectedCount,
-	))
+		len(m.duplicates),
+	)
+	if notes := m.quotas.Notes(m.clients); len(notes) > 0 {
+		stats += "\n" + strings.Join(notes, "\n")
+	}
+	return m.style.abstract.Render(stats)
 }
 
 func (m model) helpView() string {
@@ -681,47 +726,196 @@ func (m model) View() string {
 	))
 }
 
+// blobLibrary returns the name of the library an add_blob event comes
+// from. Older events do not carry provenance, the issuer is used instead.
+func blobLibrary(e edb.Event) string {
+	if len(e.Data) >= 5 {
+		return e.Data[2]
+	}
+	return e.Issuer
+}
+
+// blobID returns the blob of an add_blob event along with the identifier
+// of its publication. Older events do not carry it, it is computed from
+// the blob instead.
+func blobID(client lit.Library, e edb.Event) (*lit.Blob, string, error) {
+	b := new(lit.Blob)
+	if err := b.Unmarshal(e.Data[1]); err != nil {
+		return nil, "", fmt.Errorf("unmarshal: %w", err)
+	}
+	if len(e.Data) >= 6 && e.Data[5] != "" {
+		return b, e.Data[5], nil
+	}
+	pub, err := client.ParsePublication(*b)
+	if err != nil {
+		return nil, "", fmt.Errorf("parse publication: %w", err)
+	}
+	return b, lit.PublicationID(client, pub), nil
+}
+
+// errLegacy is returned when the edb file refers to publications by
+// their position, as done by previous versions.
+var errLegacy = errors.New("publications referenced by index, run lit-migrate on the edb file first")
+
+// dedupePublications merges the duplicates found among pubs, recording
+// new merge decisions in db. ids holds the identifier of each
+// publication, merged maps the identifiers of the duplicates already
+// recorded to the ones of the publications kept. Returns the index of
+// the publication kept for each duplicate.
+func dedupePublications(db *edb.Db, pubs []lit.Publication, ids []string, merged map[string]string, threshold float64) (map[int]int, error) {
+	index := make(map[string]int, len(ids))
+	for i, v := range ids {
+		index[v] = i
+	}
+
+	duplicates := make(map[int]int)
+	for dup, kept := range merged {
+		i, ok := index[dup]
+		if !ok {
+			continue
+		}
+		j, ok := index[kept]
+		if !ok {
+			continue
+		}
+		duplicates[i] = j
+	}
+
+	skip := make(map[int]bool, len(duplicates))
+	for k := range duplicates {
+		skip[k] = true
+	}
+	for _, d := range dedupe.Find(pubs, skip, threshold) {
+		if pubs[d.Duplicate].Review != nil {
+			// Never hide a publication that was already reviewed.
+			continue
+		}
+		if err := db.Append(&edb.Event{
+			Id:     fmt.Sprintf("%d", time.Now().UnixNano()),
+			Issuer: "dedupe",
+			Scope:  "lit",
+			Action: "merge_blobs",
+			Data: []string{
+				ids[d.Kept],
+				ids[d.Duplicate],
+				d.Reason,
+				strconv.FormatFloat(d.Score, 'f', 2, 64),
+			},
+		}); err != nil {
+			return nil, fmt.Errorf("merge_blobs: %w", err)
+		}
+		duplicates[d.Duplicate] = d.Kept
+	}
+
+	// A publication kept earlier might have been merged into a richer
+	// one since: follow the chain up to the one still standing.
+	for dup := range duplicates {
+		kept := duplicates[dup]
+		for seen := 0; seen < len(duplicates); seen++ {
+			next, ok := duplicates[kept]
+			if !ok {
+				break
+			}
+			kept = next
+		}
+		duplicates[dup] = kept
+	}
+	dups := make([]int, 0, len(duplicates))
+	for k := range duplicates {
+		dups = append(dups, k)
+	}
+	sort.Ints(dups)
+	for _, dup := range dups {
+		kept := duplicates[dup]
+		pubs[kept] = dedupe.Merge(pubs[kept], pubs[dup])
+	}
+	return duplicates, nil
+}
+
+// eventIndex returns the index of the publication an event refers to.
+func eventIndex(index map[string]int, e edb.Event) (int, error) {
+	if len(e.Data) != 2 {
+		return 0, errLegacy
+	}
+	i, ok := index[e.Data[0]]
+	if !ok {
+		return 0, fmt.Errorf("publication %q not found", e.Data[0])
+	}
+	return i, nil
+}
+
 func Main() error {
+	clients, err := libs.OpenList(*libName)
+	if err != nil {
+		return err
+	}
+	if clients, err = libs.Decorate(clients); err != nil {
+		return err
+	}
+	byName := make(map[string]lit.Library, len(clients))
+	for _, v := range clients {
+		byName[v.GetName()] = v
+	}
+
 	db, err := edb.Open(*edbPath)
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
-	client := scopus.NewClient(scopusKey)
 	query := ""
 	pubs := []lit.Publication{}
-	cursor := 0
+	sources := []lit.Library{}
+	ids := []string{}
+	index := make(map[string]int)
+	merged := make(map[string]string)
+	cursorID := ""
 	rejectedCount := 0
 	acceptedCount := 0
+	quotas := make(libs.Quotas)
 	if err := db.Revive(func(e edb.Event) error {
 		switch e.Action {
 		case "set_query":
 			query = e.Data[0]
+		case "set_quota":
+			return quotas.Revive(e)
 		case "add_blob":
-			b := new(lit.Blob)
-			if err := b.Unmarshal(e.Data[1]); err != nil {
-				return fmt.Errorf("add_blob: unmarshal: %w", err)
+			client, ok := byName[blobLibrary(e)]
+			if !ok {
+				return fmt.Errorf("add_blob: library %q not selected, see -lib", blobLibrary(e))
+			}
+			b, id, err := blobID(client, e)
+			if err != nil {
+				return fmt.Errorf("add_blob: %w", err)
+			}
+			if _, ok := index[id]; ok {
+				// Downloaded more than once.
+				return nil
 			}
 			pub, err := client.ParsePublication(*b)
 			if err != nil {
 				return fmt.Errorf("add_blob: parse publication: %w", err)
 			}
+			index[id] = len(pubs)
 			pubs = append(pubs, pub)
+			sources = append(sources, client)
+			ids = append(ids, id)
+		case "merge_blobs":
+			merged[e.Data[1]] = e.Data[0]
 		case "add_abstract":
-			index, err := strconv.Atoi(e.Data[2])
+			i, err := eventIndex(index, e)
 			if err != nil {
-				return fmt.Errorf("add_abstract: index conversion: %w", err)
+				return fmt.Errorf("add_abstract: %w", err)
 			}
 			a := new(lit.Abstract)
 			if err := a.Unmarshal(e.Data[1]); err != nil {
 				return err
 			}
-			pubs[index].Abstract = a
+			pubs[i].Abstract = a
 		case "add_review":
-			index, err := strconv.Atoi(e.Data[2])
+			i, err := eventIndex(index, e)
 			if err != nil {
-				return fmt.Errorf("add_review: index conversion: %w", err)
+				return fmt.Errorf("add_review: %w", err)
 			}
 			r := new(lit.Review)
 			if err := r.Unmarshal(e.Data[1]); err != nil {
@@ -732,23 +926,22 @@ func Main() error {
 			} else {
 				rejectedCount++
 			}
-			pubs[index].Review = r
+			pubs[i].Review = r
 		case "add_keywords":
-			index, err := strconv.Atoi(e.Data[2])
+			i, err := eventIndex(index, e)
 			if err != nil {
-				return fmt.Errorf("add_keywords: index conversion: %w", err)
+				return fmt.Errorf("add_keywords: %w", err)
 			}
 			k := new(lit.Keywords)
 			if err := k.Unmarshal(e.Data[1]); err != nil {
 				return err
 			}
-			pubs[index].Keywords = k
+			pubs[i].Keywords = k
 		case "move_cursor":
-			var err error
-			cursor, err = strconv.Atoi(e.Data[0])
-			if err != nil {
-				return fmt.Errorf("move_cursor: cursor conversion: %w", err)
+			if _, err := strconv.Atoi(e.Data[0]); err == nil {
+				return fmt.Errorf("move_cursor: %w", errLegacy)
 			}
+			cursorID = e.Data[0]
 		default:
 		}
 		return nil
@@ -756,12 +949,28 @@ func Main() error {
 		return err
 	}
 
+	if len(pubs) == 0 {
+		return fmt.Errorf("no publications found within edb. Did you run lit-get?")
+	}
+	duplicates, err := dedupePublications(db, pubs, ids, merged, *minSim)
+	if err != nil {
+		return err
+	}
+	cursor := index[cursorID]
+	if kept, ok := duplicates[cursor]; ok {
+		cursor = kept
+	}
+
 	ti := textinput.NewModel()
 	ti.CharLimit = 256 * 4
 
 	return tea.NewProgram(model{
 		db:            db,
-		client:        client,
+		clients:       clients,
+		quotas:        quotas,
+		sources:       sources,
+		ids:           ids,
+		duplicates:    duplicates,
 		style:         defaultStyle,
 		insert:        newInsertMode(),
 		normal:        newNormalMode(),
@@ -0,0 +1,590 @@
+package crossref
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+)
+
+const endpoint = "https://api.crossref.org"
+
+const (
+	KeyDOI             = lit.KeyDOI
+	KeyIssn            = lit.KeyIssn
+	KeyIsbn            = "isbn"
+	KeyAuthors         = lit.KeyAuthors
+	KeyPublisher       = lit.KeyPublisher
+	KeyPublicationName = lit.KeyPublicationName
+	KeyVolume          = lit.KeyVolume
+	KeyIssue           = lit.KeyIssue
+	KeyPageRange       = lit.KeyPageRange
+	KeyType            = "type"
+	KeyLinkAbstract    = lit.KeyLinkAbstract
+	KeyCitedByCount    = "cited_by_count"
+	KeyAffiliation     = "affiliation"
+)
+
+// FilterSep separates the free text part of a query from the Crossref
+// filter applied to it, e.g.
+// "fpga accelerator | from-pub-date:2018,type:journal-article".
+const FilterSep = "|"
+
+// ParseQuery splits q into its free text and filter parts.
+func ParseQuery(q string) (query, filter string) {
+	i := strings.LastIndex(q, FilterSep)
+	if i < 0 {
+		return strings.TrimSpace(q), ""
+	}
+	return strings.TrimSpace(q[:i]), strings.TrimSpace(q[i+len(FilterSep):])
+}
+
+type Client struct {
+	mailto     string
+	endpoint   string
+	httpClient *http.Client
+}
+
+func (c Client) DefaultPerPage() int {
+	return 100
+}
+
+func (c Client) newRequest(ctx context.Context, path string, q url.Values) *http.Request {
+	u, err := url.Parse(c.endpoint + path)
+	if err != nil {
+		panic(err)
+	}
+	if q == nil {
+		q = url.Values{}
+	}
+	if c.mailto != "" {
+		q.Set("mailto", c.mailto)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	return req
+}
+
+func (c Client) newSearchRequest(ctx context.Context, src lit.Request) *http.Request {
+	query, filter := ParseQuery(src.Query)
+
+	q := url.Values{}
+	if query != "" {
+		q.Set("query", query)
+	}
+	if filter != "" {
+		q.Set("filter", filter)
+	}
+	q.Set("rows", fmt.Sprintf("%d", src.PerPage))
+	if src.Cursor != "" {
+		q.Set("cursor", src.Cursor)
+	} else {
+		q.Set("offset", fmt.Sprintf("%d", src.PerPage*src.Page))
+	}
+	return c.newRequest(ctx, "/works", q)
+}
+
+// Crossref reports errors either as plain text or as a JSON message
+// listing what went wrong.
+func extractError(r *http.Response) error {
+	var p struct {
+		Message []struct {
+			Message string `json:"message"`
+		} `json:"message"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&p); err == nil && len(p.Message) > 0 {
+		msgs := make([]string, len(p.Message))
+		for i, v := range p.Message {
+			msgs[i] = v.Message
+		}
+		return lit.NewStatusError(r, strings.Join(msgs, "; "))
+	}
+	return lit.NewStatusError(r, "")
+}
+
+func (c Client) get(req *http.Request, message interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return extractError(resp)
+	}
+
+	var p struct {
+		Status  string          `json:"status"`
+		Message json.RawMessage `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return err
+	}
+	if p.Status != "ok" {
+		return fmt.Errorf("unexpected status %q", p.Status)
+	}
+	return json.Unmarshal(p.Message, message)
+}
+
+type workList struct {
+	Total      int               `json:"total-results"`
+	NextCursor string            `json:"next-cursor"`
+	Items      []json.RawMessage `json:"items"`
+}
+
+func (c Client) GetLiterature(ctx context.Context, req lit.Request) (lit.Response, error) {
+	var l workList
+	if err := c.get(c.newSearchRequest(ctx, req), &l); err != nil {
+		return lit.Response{}, err
+	}
+	blobs := make([]lit.Blob, len(l.Items))
+	for i, v := range l.Items {
+		blobs[i] = lit.Blob(v)
+	}
+	return lit.Response{
+		Req:   req,
+		Blobs: blobs,
+		Next:  l.NextCursor,
+	}, nil
+}
+
+func (c Client) GetMaxLiterature(ctx context.Context, req lit.Request) (int, error) {
+	req.Page = 0
+	req.PerPage = 0
+	var l workList
+	if err := c.get(c.newSearchRequest(ctx, req), &l); err != nil {
+		return 0, err
+	}
+	return l.Total, nil
+}
+
+func (c Client) GetRateLimit() time.Duration {
+	// https://api.crossref.org/swagger-ui/index.html, polite pool users
+	// (mailto) are granted a higher rate.
+	if c.mailto != "" {
+		return time.Millisecond * 1000 / time.Duration(10)
+	}
+	return time.Millisecond * 1000 / time.Duration(5)
+}
+
+// OffsetLimit returns the number of results reachable through offset,
+// see lit.CursorPager.
+func (c Client) OffsetLimit() int {
+	return 10000
+}
+
+func (c Client) ConcurrencyLimit() int {
+	if c.mailto != "" {
+		return 3
+	}
+	return 1
+}
+
+func (c Client) PrettyPrint(b lit.Blob, dst *bytes.Buffer) error {
+	return json.Indent(dst, []byte(b), "", "\t")
+}
+
+type affiliation struct {
+	Name string `json:"name"`
+}
+
+type author struct {
+	Given        string        `json:"given"`
+	Family       string        `json:"family"`
+	Name         string        `json:"name"`
+	Sequence     string        `json:"sequence"`
+	ORCID        string        `json:"ORCID"`
+	Affiliations []affiliation `json:"affiliation"`
+}
+
+func (a author) FullName() string {
+	switch {
+	case a.Name != "":
+		return a.Name
+	case a.Given == "":
+		return a.Family
+	default:
+		return a.Family + ", " + a.Given
+	}
+}
+
+type date struct {
+	Parts [][]int `json:"date-parts"`
+}
+
+func (d *date) Time() (time.Time, bool) {
+	// Unknown dates are encoded as [[null]].
+	if d == nil || len(d.Parts) == 0 || len(d.Parts[0]) == 0 || d.Parts[0][0] == 0 {
+		return time.Time{}, false
+	}
+	// Missing month and day default to the first.
+	p := append(append([]int{}, d.Parts[0]...), 1, 1)
+	month, day := p[1], p[2]
+	if month == 0 {
+		month = 1
+	}
+	if day == 0 {
+		day = 1
+	}
+	return time.Date(p[0], time.Month(month), day, 0, 0, 0, 0, time.UTC), true
+}
+
+type work struct {
+	DOI            string   `json:"DOI"`
+	Title          []string `json:"title"`
+	Authors        []author `json:"author"`
+	Publisher      string   `json:"publisher"`
+	ContainerTitle []string `json:"container-title"`
+	Volume         string   `json:"volume"`
+	Issue          string   `json:"issue"`
+	Page           string   `json:"page"`
+	Type           string   `json:"type"`
+	Issn           []string `json:"ISSN"`
+	Isbn           []string `json:"ISBN"`
+	URL            string   `json:"URL"`
+	Abstract       string   `json:"abstract"`
+	CitedByCount   int      `json:"is-referenced-by-count"`
+	Language       string   `json:"language"`
+
+	Published       *date `json:"published"`
+	PublishedPrint  *date `json:"published-print"`
+	PublishedOnline *date `json:"published-online"`
+	Issued          *date `json:"issued"`
+	Created         *date `json:"created"`
+}
+
+func (w work) CoverDate() (time.Time, error) {
+	for _, d := range []*date{w.Published, w.PublishedPrint, w.PublishedOnline, w.Issued, w.Created} {
+		if t, ok := d.Time(); ok {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("parse publication date: no date available")
+}
+
+func first(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	return s[0]
+}
+
+func (w work) Creator() string {
+	for _, v := range w.Authors {
+		if v.Sequence == "first" {
+			return v.FullName()
+		}
+	}
+	if len(w.Authors) > 0 {
+		return w.Authors[0].FullName()
+	}
+	return ""
+}
+
+func (w work) AuthorNames() string {
+	names := make([]string, len(w.Authors))
+	for i, v := range w.Authors {
+		names[i] = v.FullName()
+	}
+	return strings.Join(names, " and ")
+}
+
+func (w work) AuthorList() []lit.Author {
+	if len(w.Authors) == 0 {
+		return nil
+	}
+	authors := make([]lit.Author, len(w.Authors))
+	for i, v := range w.Authors {
+		affiliations := make([]string, len(v.Affiliations))
+		for j, a := range v.Affiliations {
+			affiliations[j] = a.Name
+		}
+		id := v.ORCID
+		authors[i] = lit.Author{
+			Name:        v.FullName(),
+			ORCID:       id[strings.LastIndex(id, "/")+1:],
+			Affiliation: strings.Join(affiliations, "; "),
+		}
+	}
+	return authors
+}
+
+// DocumentType maps the type of the work onto the types of lit.
+func (w work) DocumentType() lit.DocumentType {
+	switch w.Type {
+	case "journal-article":
+		return lit.TypeArticle
+	case "proceedings-article":
+		return lit.TypeConferencePaper
+	case "book-chapter", "book-section", "book-part":
+		return lit.TypeBookChapter
+	case "book", "monograph", "edited-book", "reference-book":
+		return lit.TypeBook
+	case "posted-content":
+		return lit.TypePreprint
+	case "dissertation":
+		return lit.TypeThesis
+	case "report":
+		return lit.TypeReport
+	case "":
+		return ""
+	default:
+		return lit.TypeOther
+	}
+}
+
+func (w work) Affiliation() string {
+	seen := make(map[string]bool)
+	affiliations := []string{}
+	for _, a := range w.Authors {
+		for _, v := range a.Affiliations {
+			if v.Name == "" || seen[v.Name] {
+				continue
+			}
+			seen[v.Name] = true
+			affiliations = append(affiliations, v.Name)
+		}
+	}
+	return strings.Join(affiliations, "; ")
+}
+
+func (w work) Values() map[string]string {
+	return map[string]string{
+		KeyDOI:             w.DOI,
+		KeyIssn:            first(w.Issn),
+		KeyIsbn:            first(w.Isbn),
+		KeyAuthors:         w.AuthorNames(),
+		KeyPublisher:       w.Publisher,
+		KeyPublicationName: first(w.ContainerTitle),
+		KeyVolume:          w.Volume,
+		KeyIssue:           w.Issue,
+		KeyPageRange:       w.Page,
+		KeyType:            w.Type,
+		KeyLinkAbstract:    w.URL,
+		KeyCitedByCount:    fmt.Sprintf("%d", w.CitedByCount),
+		KeyAffiliation:     w.Affiliation(),
+	}
+}
+
+// JATSText strips the JATS markup Crossref abstracts are encoded with.
+func JATSText(s string) string {
+	d := xml.NewDecoder(strings.NewReader(s))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	var buf strings.Builder
+	for {
+		t, err := d.Token()
+		if err != nil {
+			break
+		}
+		switch t := t.(type) {
+		case xml.CharData:
+			buf.Write(t)
+		case xml.StartElement:
+			// Keeps words belonging to different paragraphs apart.
+			buf.WriteString(" ")
+		}
+	}
+	text := strings.Join(strings.Fields(buf.String()), " ")
+	return strings.TrimPrefix(text, "Abstract ")
+}
+
+func parseWork(b []byte) (lit.Publication, error) {
+	var w work
+	if err := json.Unmarshal(b, &w); err != nil {
+		return lit.Publication{}, err
+	}
+	coverDate, err := w.CoverDate()
+	if err != nil {
+		return lit.Publication{}, fmt.Errorf("work %s: %w", w.DOI, err)
+	}
+
+	citedBy := w.CitedByCount
+	p := lit.Publication{
+		Title:     strings.Join(strings.Fields(first(w.Title)), " "),
+		CoverDate: coverDate,
+		Creator:   w.Creator(),
+		Authors:   w.AuthorList(),
+		IDs: lit.Identifiers{
+			DOI:  w.DOI,
+			ISBN: first(w.Isbn),
+		},
+		Venue:    first(w.ContainerTitle),
+		Volume:   w.Volume,
+		Issue:    w.Issue,
+		Pages:    w.Page,
+		Type:     w.DocumentType(),
+		Language: w.Language,
+		CitedBy:  &citedBy,
+		Values:   w.Values(),
+	}
+	if w.Abstract != "" {
+		p.Abstract = &lit.Abstract{Text: JATSText(w.Abstract)}
+	}
+	return p, nil
+}
+
+func (c Client) ParsePublication(b lit.Blob) (lit.Publication, error) {
+	return parseWork([]byte(b))
+}
+
+// GetWork returns the publication identified by doi.
+func (c Client) GetWork(ctx context.Context, doi string) (lit.Publication, error) {
+	var raw json.RawMessage
+	if err := c.get(c.newRequest(ctx, "/works/"+url.PathEscape(doi), nil), &raw); err != nil {
+		return lit.Publication{}, fmt.Errorf("get work %s: %w", doi, err)
+	}
+	return parseWork(raw)
+}
+
+func (c Client) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	if p.Abstract != nil {
+		return *p.Abstract, nil
+	}
+
+	doi := p.DOI()
+	if doi == "" {
+		return lit.Abstract{}, fmt.Errorf("publication %q has no DOI", p.Title)
+	}
+	w, err := c.GetWork(ctx, doi)
+	if err != nil {
+		return lit.Abstract{}, err
+	}
+	if w.Abstract == nil {
+		return lit.Abstract{}, fmt.Errorf("work %s has no abstract", doi)
+	}
+	return *w.Abstract, nil
+}
+
+// Enrich looks p up by DOI and fills the fields it is missing, such as
+// the full author list, publisher, issue and pages, with the data
+// registered in Crossref. Fields already present are left untouched. p
+// can come from any library, as long as it carries a DOI, in its IDs or
+// as a lit.KeyDOI value.
+func (c Client) Enrich(ctx context.Context, p *lit.Publication) error {
+	doi := p.DOI()
+	if doi == "" {
+		return fmt.Errorf("enrich %q: missing DOI", p.Title)
+	}
+	w, err := c.GetWork(ctx, doi)
+	if err != nil {
+		return fmt.Errorf("enrich: %w", err)
+	}
+
+	if p.Values == nil {
+		p.Values = make(map[string]string)
+	}
+	for _, k := range []string{
+		KeyAuthors,
+		KeyPublisher,
+		KeyPublicationName,
+		KeyVolume,
+		KeyIssue,
+		KeyPageRange,
+		KeyIssn,
+	} {
+		if p.Values[k] == "" && w.Values[k] != "" {
+			p.Values[k] = w.Values[k]
+		}
+	}
+	if p.Title == "" {
+		p.Title = w.Title
+	}
+	if p.Creator == "" {
+		p.Creator = w.Creator
+	}
+	if p.CoverDate.IsZero() {
+		p.CoverDate = w.CoverDate
+	}
+	if p.Abstract == nil {
+		p.Abstract = w.Abstract
+	}
+	p.Complete(w)
+	return nil
+}
+
+// HTTPClient returns the client used to talk to the API, see
+// lit.HTTPLibrary.
+func (c Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+func (c Client) GetName() string {
+	return "Crossref"
+}
+
+func (c Client) ToBibTeX(p lit.Publication) bibtex.Reference {
+	entry := p.BibTeXEntry()
+	venue := p.Values[KeyPublicationName]
+
+	switch p.Values[KeyType] {
+	case "journal-article":
+		return bibtex.Article{
+			Entry:     entry,
+			Journal:   venue,
+			Volume:    p.Value(KeyVolume),
+			Number:    p.Value(KeyIssue),
+			PageRange: p.Value(KeyPageRange),
+		}
+	case "proceedings-article":
+		return bibtex.InProceedings{
+			Entry:     entry,
+			BookTitle: venue,
+			PageRange: p.Value(KeyPageRange),
+			Publisher: p.Value(KeyPublisher),
+		}
+	case "book-chapter", "book-section", "book-part":
+		return bibtex.InCollection{
+			Entry:     entry,
+			BookTitle: venue,
+			Publisher: p.Values[KeyPublisher],
+			PageRange: p.Value(KeyPageRange),
+		}
+	case "book", "monograph", "edited-book", "reference-book":
+		return bibtex.Book{
+			Entry:     entry,
+			Publisher: p.Values[KeyPublisher],
+		}
+	default:
+		note := fmt.Sprintf("%q", p.Values)
+		return bibtex.Misc{
+			Entry: entry,
+			Note:  &note,
+		}
+	}
+}
+
+func (c Client) ReferenceLink(p lit.Publication) string {
+	return "https://doi.org/" + p.Values[KeyDOI]
+}
+
+// NewClient returns a client for the Crossref REST API. No authentication
+// is required, though providing an email address in mailto grants access
+// to the "polite pool".
+func NewClient(mailto string) Client {
+	tr := &http.Transport{
+		MaxIdleConns:       10,
+		IdleConnTimeout:    15 * time.Second,
+		DisableCompression: false,
+	}
+
+	return Client{
+		mailto:     mailto,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Transport: tr},
+	}
+}
@@ -0,0 +1,297 @@
+package crossref
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/littest"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c := NewClient("reviewer@example.com")
+	c.endpoint = srv.URL
+	return c
+}
+
+func TestParseQuery(t *testing.T) {
+	tt := []struct {
+		in     string
+		query  string
+		filter string
+	}{
+		{"fpga accelerator", "fpga accelerator", ""},
+		{"fpga accelerator | from-pub-date:2018,type:journal-article", "fpga accelerator", "from-pub-date:2018,type:journal-article"},
+		{"|type:book", "", "type:book"},
+	}
+	for _, v := range tt {
+		query, filter := ParseQuery(v.in)
+		if query != v.query || filter != v.filter {
+			t.Errorf("%q: have (%q, %q), want (%q, %q)", v.in, query, filter, v.query, v.filter)
+		}
+	}
+}
+
+func TestGetLiterature(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		want := map[string]string{
+			"query":  "fpga accelerator",
+			"filter": "from-pub-date:2018",
+			"rows":   "2",
+			"offset": "4",
+			"mailto": "reviewer@example.com",
+		}
+		for k, v := range want {
+			if have := q.Get(k); have != v {
+				t.Errorf("%s: have %q, want %q", k, have, v)
+			}
+		}
+		fmt.Fprint(w, worksPage)
+	})
+
+	resp, err := c.GetLiterature(context.Background(), lit.Request{
+		Query:   "fpga accelerator | from-pub-date:2018",
+		Page:    2,
+		PerPage: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 2 {
+		t.Fatalf("blobs: have %d, want 2", resp.Len())
+	}
+	if resp.Next == "" {
+		t.Fatal("next cursor not set")
+	}
+
+	p, err := c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Can FPGAs Beat GPUs in Accelerating Next-Generation Deep Neural Networks?"; p.Title != want {
+		t.Fatalf("title: have %q, want %q", p.Title, want)
+	}
+	if want := time.Date(2017, 2, 22, 0, 0, 0, 0, time.UTC); !p.CoverDate.Equal(want) {
+		t.Fatalf("cover date: have %v, want %v", p.CoverDate, want)
+	}
+	if p.Abstract == nil || p.Abstract.Text != "Current-generation DNNs rely on GPUs. FPGAs are an alternative." {
+		t.Fatalf("abstract: have %+v", p.Abstract)
+	}
+	ref := c.ToBibTeX(p)
+	if ref.EntryType() != bibtex.EntryTypeInProceedings {
+		t.Fatalf("entry type: have %q, want %q", ref.EntryType(), bibtex.EntryTypeInProceedings)
+	}
+	if want := "ACM"; ref.Fields()["publisher"] != want {
+		t.Fatalf("publisher: have %q, want %q", ref.Fields()["publisher"], want)
+	}
+	if p.Type != lit.TypeConferencePaper || p.IDs.DOI == "" || p.CitedBy == nil || len(p.Authors) == 0 {
+		t.Fatalf("unexpected metadata %s, %+v, %v, %+v", p.Type, p.IDs, p.CitedBy, p.Authors)
+	}
+
+	p, err = c.ParsePublication(resp.Blobs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref := c.ToBibTeX(p); ref.EntryType() != bibtex.EntryTypeArticle || ref.Fields()["number"] != "3" {
+		t.Fatalf("unexpected article: %v %v", ref.EntryType(), ref.Fields())
+	}
+	if p.Type != lit.TypeArticle || p.Issue != "3" {
+		t.Fatalf("unexpected article metadata %s, issue %q", p.Type, p.Issue)
+	}
+}
+
+func TestGetMaxLiterature(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have := r.URL.Query().Get("rows"); have != "0" {
+			t.Errorf("rows: have %q, want 0", have)
+		}
+		fmt.Fprint(w, worksPage)
+	})
+	have, err := c.GetMaxLiterature(context.Background(), lit.Request{Query: "fpga"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have != 2048 {
+		t.Fatalf("max literature: have %d, want 2048", have)
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/works/10.1145/3020078.3021740"; r.URL.Path != want {
+			t.Errorf("path: have %q, want %q", r.URL.Path, want)
+		}
+		fmt.Fprint(w, singleWork)
+	})
+
+	// As returned by a Scopus search: no publisher, first author only.
+	p := lit.Publication{
+		Title:     "Can FPGAs beat GPUs in accelerating next-generation deep neural networks?",
+		CoverDate: time.Date(2017, 2, 22, 0, 0, 0, 0, time.UTC),
+		Creator:   "Nurvitadhi E.",
+		Values: map[string]string{
+			"eid":                  "2-s2.0-85016028368",
+			lit.KeyDOI:             "10.1145/3020078.3021740",
+			lit.KeyPageRange:       "5-14",
+			lit.KeyPublicationName: "FPGA 2017",
+		},
+	}
+	if err := c.Enrich(context.Background(), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"eid":                  "2-s2.0-85016028368",
+		lit.KeyAuthors:         "Nurvitadhi, Eriko and Venkatesh, Ganesh",
+		lit.KeyPublisher:       "ACM",
+		lit.KeyPageRange:       "5-14",
+		lit.KeyPublicationName: "FPGA 2017",
+		lit.KeyIssue:           "7",
+	}
+	for k, v := range want {
+		if have := p.Values[k]; have != v {
+			t.Errorf("%s: have %q, want %q", k, have, v)
+		}
+	}
+	if p.Creator != "Nurvitadhi E." {
+		t.Errorf("creator should not be overwritten, have %q", p.Creator)
+	}
+	if p.Abstract == nil {
+		t.Errorf("missing abstract should have been filled")
+	}
+	if len(p.Authors) != 2 || p.IDs.DOI != "10.1145/3020078.3021740" || p.Issue != "7" {
+		t.Errorf("unexpected metadata %+v, %+v, issue %q", p.Authors, p.IDs, p.Issue)
+	}
+}
+
+func TestEnrichWithoutDOI(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("no request expected")
+	})
+	if err := c.Enrich(context.Background(), &lit.Publication{Title: "x"}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"status":"failed","message-type":"validation-failure","message":[{"type":"filter-not-available","value":"foo","message":"Filter foo specified but there is no such filter for this route."}]}`)
+	})
+	_, err := c.GetLiterature(context.Background(), lit.Request{Query: "fpga | foo:1", PerPage: 20})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "400 Bad Request: Filter foo specified but there is no such filter for this route."; err.Error() != want {
+		t.Fatalf("error: have %q, want %q", err, want)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	var page struct {
+		Message struct {
+			Items []json.RawMessage `json:"items"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(worksPage), &page); err != nil {
+		t.Fatal(err)
+	}
+	works := littest.Records(42, page.Message.Items, func(i int, w map[string]interface{}) {
+		w["DOI"] = fmt.Sprintf("10.1145/3020078.%d", i)
+		w["title"] = []string{fmt.Sprintf("%s, part %d", w["title"].([]interface{})[0], i)}
+	})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		rows, _ := strconv.Atoi(q.Get("rows"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "ok",
+			"message": map[string]interface{}{
+				"total-results": len(works),
+				"items":         littest.Page(works, offset, rows),
+			},
+		})
+	})
+	littest.RunConformance(t, c, littest.Fixture{Query: "fpga", PerPage: 10, Max: 42})
+}
+
+// Trimmed down response recorded from
+// https://api.crossref.org/works?query=fpga+accelerator&rows=2&cursor=*
+const worksPage = `{
+  "status": "ok",
+  "message-type": "work-list",
+  "message-version": "1.0.0",
+  "message": {
+    "facets": {},
+    "next-cursor": "DnF1ZXJ5VGhlbkZldGNoBgAAAAAA",
+    "total-results": 2048,
+    "items": [
+      {
+        "DOI": "10.1145/3020078.3021740",
+        "type": "proceedings-article",
+        "title": ["Can FPGAs Beat GPUs in Accelerating Next-Generation Deep Neural Networks?"],
+        "publisher": "ACM",
+        "container-title": ["Proceedings of the 2017 ACM/SIGDA International Symposium on Field-Programmable Gate Arrays"],
+        "page": "5-14",
+        "author": [
+          {"given": "Eriko", "family": "Nurvitadhi", "sequence": "first", "affiliation": [{"name": "Intel Corporation"}]},
+          {"given": "Ganesh", "family": "Venkatesh", "sequence": "additional", "affiliation": []}
+        ],
+        "published": {"date-parts": [[2017, 2, 22]]},
+        "issued": {"date-parts": [[2017, 2, 22]]},
+        "abstract": "<jats:title>Abstract</jats:title><jats:p>Current-generation DNNs rely on GPUs.</jats:p><jats:p>FPGAs are an alternative.</jats:p>",
+        "URL": "http://dx.doi.org/10.1145/3020078.3021740",
+        "is-referenced-by-count": 320
+      },
+      {
+        "DOI": "10.1109/tcad.2018.2857078",
+        "type": "journal-article",
+        "title": ["Angel-Eye: A Complete Design Flow for Mapping CNN Onto Embedded FPGA"],
+        "publisher": "Institute of Electrical and Electronics Engineers (IEEE)",
+        "container-title": ["IEEE Transactions on Computer-Aided Design of Integrated Circuits and Systems"],
+        "volume": "37",
+        "issue": "3",
+        "page": "35-47",
+        "ISSN": ["0278-0070", "1937-4151"],
+        "author": [
+          {"given": "Kaiyuan", "family": "Guo", "sequence": "first", "affiliation": []}
+        ],
+        "published": {"date-parts": [[2018]]},
+        "URL": "http://dx.doi.org/10.1109/tcad.2018.2857078"
+      }
+    ],
+    "items-per-page": 2
+  }
+}`
+
+const singleWork = `{
+  "status": "ok",
+  "message-type": "work",
+  "message-version": "1.0.0",
+  "message": {
+    "DOI": "10.1145/3020078.3021740",
+    "type": "proceedings-article",
+    "title": ["Can FPGAs Beat GPUs in Accelerating Next-Generation Deep Neural Networks?"],
+    "publisher": "ACM",
+    "container-title": ["Proceedings of the 2017 ACM/SIGDA International Symposium on Field-Programmable Gate Arrays"],
+    "issue": "7",
+    "page": "5-14",
+    "author": [
+      {"given": "Eriko", "family": "Nurvitadhi", "sequence": "first", "affiliation": []},
+      {"given": "Ganesh", "family": "Venkatesh", "sequence": "additional", "affiliation": []}
+    ],
+    "published": {"date-parts": [[2017, 2, 22]]},
+    "abstract": "<jats:p>Current-generation DNNs rely on GPUs.</jats:p>"
+  }
+}`
@@ -0,0 +1,504 @@
+package dblp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+)
+
+const endpoint = "https://dblp.org/search/publ/api"
+
+// maxWindow is the maximum offset+hits accepted by the search API.
+const maxWindow = 10000
+
+const (
+	KeyDBLPKey         = "dblp_key"
+	KeyLinkAbstract    = lit.KeyLinkAbstract
+	KeyLinkEE          = "link_ee"
+	KeyDOI             = lit.KeyDOI
+	KeyAuthors         = lit.KeyAuthors
+	KeyPublisher       = lit.KeyPublisher
+	KeyPublicationName = lit.KeyPublicationName
+	KeyVolume          = lit.KeyVolume
+	KeyIssue           = lit.KeyIssue
+	KeyPageRange       = lit.KeyPageRange
+	KeyType            = "type"
+)
+
+// Publication types as reported by DBLP.
+const (
+	TypeArticle       = "Journal Articles"
+	TypeInProceedings = "Conference and Workshop Papers"
+	TypeInCollection  = "Parts in Books or Collections"
+	TypeBook          = "Books and Theses"
+	TypeInformal      = "Informal and Other Publications"
+)
+
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func (c Client) DefaultPerPage() int {
+	return 100
+}
+
+func (c Client) newRequest(ctx context.Context, src lit.Request) *http.Request {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		panic(err)
+	}
+	q := url.Values{}
+	q.Set("q", src.Query)
+	q.Set("format", "json")
+	q.Set("h", fmt.Sprintf("%d", src.PerPage))
+	q.Set("f", fmt.Sprintf("%d", src.Page*src.PerPage))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	return req
+}
+
+func extractError(r *http.Response) error {
+	var p searchResults
+	if err := json.NewDecoder(r.Body).Decode(&p); err == nil && p.Result.Status.Text != "" {
+		return lit.NewStatusError(r, p.Result.Status.Text)
+	}
+	return lit.NewStatusError(r, "")
+}
+
+type status struct {
+	Code string `json:"@code"`
+	Text string `json:"text"`
+}
+
+type hit struct {
+	Info json.RawMessage `json:"info"`
+}
+
+type hits struct {
+	Total string `json:"@total"`
+	Hit   []hit  `json:"hit"`
+}
+
+type searchResults struct {
+	Result struct {
+		Status status `json:"status"`
+		Hits   hits   `json:"hits"`
+	} `json:"result"`
+}
+
+func (c Client) search(ctx context.Context, req lit.Request) (searchResults, error) {
+	resp, err := c.httpClient.Do(c.newRequest(ctx, req))
+	if err != nil {
+		return searchResults{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return searchResults{}, extractError(resp)
+	}
+
+	var p searchResults
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return searchResults{}, err
+	}
+	return p, nil
+}
+
+func (c Client) GetLiterature(ctx context.Context, req lit.Request) (lit.Response, error) {
+	if req.Page*req.PerPage >= maxWindow {
+		return lit.Response{}, fmt.Errorf("offset %d exceeds the %d results DBLP search can reach, refine the query", req.Page*req.PerPage, maxWindow)
+	}
+	if req.Page*req.PerPage+req.PerPage > maxWindow {
+		req.PerPage = maxWindow - req.Page*req.PerPage
+	}
+	p, err := c.search(ctx, req)
+	if err != nil {
+		return lit.Response{}, err
+	}
+	blobs := make([]lit.Blob, len(p.Result.Hits.Hit))
+	for i, v := range p.Result.Hits.Hit {
+		blobs[i] = lit.Blob(v.Info)
+	}
+	return lit.Response{
+		Req:   req,
+		Blobs: blobs,
+	}, nil
+}
+
+func (c Client) GetMaxLiterature(ctx context.Context, req lit.Request) (int, error) {
+	req.Page = 0
+	req.PerPage = 0
+	p, err := c.search(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	total, err := strconv.Atoi(p.Result.Hits.Total)
+	if err != nil {
+		return 0, fmt.Errorf("parse total hits: %w", err)
+	}
+	// Results past the window cannot be downloaded.
+	if total > maxWindow {
+		return maxWindow, nil
+	}
+	return total, nil
+}
+
+// Window returns the number of results of a query the search reaches,
+// see lit.Windowed.
+func (c Client) Window() int {
+	return maxWindow
+}
+
+func (c Client) GetRateLimit() time.Duration {
+	// DBLP does not publish its limits but answers with 429s to
+	// clients that hammer it.
+	return time.Second
+}
+
+func (c Client) ConcurrencyLimit() int {
+	return 1
+}
+
+func (c Client) PrettyPrint(b lit.Blob, dst *bytes.Buffer) error {
+	return json.Indent(dst, []byte(b), "", "\t")
+}
+
+// list decodes DBLP fields that hold either a single value or an array of
+// them, depending on how many values are available.
+type list []json.RawMessage
+
+func (l *list) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '[' {
+		var raw []json.RawMessage
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return err
+		}
+		*l = raw
+		return nil
+	}
+	*l = list{json.RawMessage(b)}
+	return nil
+}
+
+func (l list) Strings() []string {
+	s := make([]string, 0, len(l))
+	for _, v := range l {
+		var str string
+		if err := json.Unmarshal(v, &str); err == nil {
+			s = append(s, str)
+		}
+	}
+	return s
+}
+
+type author struct {
+	PID  string `json:"@pid"`
+	Text string `json:"text"`
+}
+
+// homonymSuffix matches the number DBLP appends to names shared by
+// different authors, e.g. "Wei Zhang 0001".
+var homonymSuffix = regexp.MustCompile(`\s+\d{4}$`)
+
+func (a author) Name() string {
+	return homonymSuffix.ReplaceAllString(a.Text, "")
+}
+
+type info struct {
+	Authors struct {
+		Author list `json:"author"`
+	} `json:"authors"`
+	Title     string `json:"title"`
+	Venue     list   `json:"venue"`
+	Volume    string `json:"volume"`
+	Number    string `json:"number"`
+	Pages     string `json:"pages"`
+	Year      string `json:"year"`
+	Type      string `json:"type"`
+	Publisher string `json:"publisher"`
+	Key       string `json:"key"`
+	DOI       string `json:"doi"`
+	EE        list   `json:"ee"`
+	URL       string `json:"url"`
+}
+
+func (i info) authorList() []author {
+	authors := make([]author, 0, len(i.Authors.Author))
+	for _, v := range i.Authors.Author {
+		var a author
+		if err := json.Unmarshal(v, &a); err == nil {
+			authors = append(authors, a)
+		}
+	}
+	return authors
+}
+
+func (i info) Creator() string {
+	authors := i.authorList()
+	if len(authors) == 0 {
+		return ""
+	}
+	return authors[0].Name()
+}
+
+func (i info) AuthorNames() string {
+	authors := i.authorList()
+	names := make([]string, len(authors))
+	for j, v := range authors {
+		names[j] = v.Name()
+	}
+	return strings.Join(names, " and ")
+}
+
+func (i info) AuthorList() []lit.Author {
+	authors := i.authorList()
+	if len(authors) == 0 {
+		return nil
+	}
+	list := make([]lit.Author, len(authors))
+	for j, v := range authors {
+		list[j] = lit.Author{Name: v.Name()}
+	}
+	return list
+}
+
+// arxivEE matches the arXiv links of informal publications.
+var arxivEE = regexp.MustCompile(`^https?://arxiv\.org/abs/(.+?)(v\d+)?$`)
+
+// ArxivID returns the arXiv identifier of the record, if it links to it.
+func (i info) ArxivID() string {
+	for _, v := range i.EE.Strings() {
+		if m := arxivEE.FindStringSubmatch(v); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// DocumentType maps the type of the record onto the types of lit.
+// Informal publications on arXiv are preprints.
+func (i info) DocumentType() lit.DocumentType {
+	switch i.Type {
+	case TypeArticle:
+		return lit.TypeArticle
+	case TypeInProceedings:
+		return lit.TypeConferencePaper
+	case TypeInCollection:
+		return lit.TypeBookChapter
+	case TypeBook:
+		return lit.TypeBook
+	case TypeInformal:
+		if i.ArxivID() != "" {
+			return lit.TypePreprint
+		}
+		return lit.TypeOther
+	case "":
+		return ""
+	default:
+		return lit.TypeOther
+	}
+}
+
+func (i info) GetTitle() string {
+	// DBLP titles are terminated by a period, as in its BibTeX export.
+	return strings.TrimSuffix(i.Title, ".")
+}
+
+func (i info) CoverDate() (time.Time, error) {
+	year, err := strconv.Atoi(i.Year)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse year: %w", err)
+	}
+	return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), nil
+}
+
+func (i info) Values() map[string]string {
+	var ee string
+	if links := i.EE.Strings(); len(links) > 0 {
+		ee = links[0]
+	}
+	return map[string]string{
+		KeyDBLPKey:         i.Key,
+		KeyLinkAbstract:    i.URL,
+		KeyLinkEE:          ee,
+		KeyDOI:             i.DOI,
+		KeyAuthors:         i.AuthorNames(),
+		KeyPublisher:       i.Publisher,
+		KeyPublicationName: strings.Join(i.Venue.Strings(), ", "),
+		KeyVolume:          i.Volume,
+		KeyIssue:           i.Number,
+		KeyPageRange:       i.Pages,
+		KeyType:            i.Type,
+	}
+}
+
+func (c Client) ParsePublication(b lit.Blob) (lit.Publication, error) {
+	var i info
+	if err := json.Unmarshal([]byte(b), &i); err != nil {
+		return lit.Publication{}, err
+	}
+	coverDate, err := i.CoverDate()
+	if err != nil {
+		return lit.Publication{}, fmt.Errorf("record %s: %w", i.Key, err)
+	}
+	values := i.Values()
+	return lit.Publication{
+		Title:     i.GetTitle(),
+		CoverDate: coverDate,
+		Creator:   i.Creator(),
+		Authors:   i.AuthorList(),
+		IDs: lit.Identifiers{
+			DOI:   i.DOI,
+			ArXiv: i.ArxivID(),
+		},
+		Venue:  values[KeyPublicationName],
+		Volume: i.Volume,
+		Issue:  i.Number,
+		Pages:  i.Pages,
+		Type:   i.DocumentType(),
+		Values: values,
+	}, nil
+}
+
+// AbstractClient is a Client asking a fallback library for the abstracts
+// DBLP does not store.
+type AbstractClient struct {
+	Client
+	fallback lit.Library
+}
+
+// WithAbstracts returns a copy of c looking abstracts up with fallback,
+// which is expected to find publications by DOI.
+func (c Client) WithAbstracts(fallback lit.Library) AbstractClient {
+	return AbstractClient{Client: c, fallback: fallback}
+}
+
+// GetAbstract asks the fallback library for the abstract of p.
+func (c AbstractClient) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	if p.Abstract != nil {
+		return *p.Abstract, nil
+	}
+	var abstracts lit.AbstractProvider
+	if c.fallback == nil || !lit.As(c.fallback, &abstracts) {
+		return lit.Abstract{}, fmt.Errorf("DBLP has no abstracts and no fallback library provides them: %w", lit.ErrUnsupported)
+	}
+	doi := p.DOI()
+	if doi == "" {
+		return lit.Abstract{}, fmt.Errorf("record %s has no DOI to look its abstract up with %s", p.Values[KeyDBLPKey], c.fallback.GetName())
+	}
+	// Only the DOI is forwarded, other values would be misinterpreted
+	// by the fallback library.
+	abs, err := abstracts.GetAbstract(ctx, lit.Publication{
+		Title:  p.Title,
+		IDs:    lit.Identifiers{DOI: doi},
+		Values: map[string]string{KeyDOI: doi},
+	})
+	if err != nil {
+		return lit.Abstract{}, fmt.Errorf("%s: %w", c.fallback.GetName(), err)
+	}
+	return abs, nil
+}
+
+// HTTPClient returns the client used to talk to the API, see
+// lit.HTTPLibrary.
+func (c Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+func (c Client) GetName() string {
+	return "DBLP"
+}
+
+// NativeID returns the DBLP key of p, e.g. conf/fpga/NurvitadhiVSMLO17.
+func (c Client) NativeID(p lit.Publication) string {
+	return p.Values[KeyDBLPKey]
+}
+
+func (c Client) ToBibTeX(p lit.Publication) bibtex.Reference {
+	// The reference points to the electronic edition, not to the DBLP
+	// record.
+	entry := p.BibTeXEntry()
+	entry.Url = p.Value(KeyLinkEE)
+	venue := p.Values[KeyPublicationName]
+
+	switch p.Values[KeyType] {
+	case TypeArticle:
+		return bibtex.Article{
+			Entry:     entry,
+			Journal:   venue,
+			Volume:    p.Value(KeyVolume),
+			Number:    p.Value(KeyIssue),
+			PageRange: p.Value(KeyPageRange),
+		}
+	case TypeInProceedings:
+		return bibtex.InProceedings{
+			Entry:     entry,
+			BookTitle: venue,
+			PageRange: p.Value(KeyPageRange),
+			Publisher: p.Value(KeyPublisher),
+		}
+	case TypeInCollection:
+		return bibtex.InCollection{
+			Entry:     entry,
+			BookTitle: venue,
+			Publisher: p.Values[KeyPublisher],
+			PageRange: p.Value(KeyPageRange),
+		}
+	case TypeBook:
+		return bibtex.Book{
+			Entry:     entry,
+			Publisher: p.Values[KeyPublisher],
+		}
+	default:
+		note := "DBLP: " + p.Values[KeyDBLPKey]
+		if venue != "" {
+			note = venue + ", " + note
+		}
+		return bibtex.Misc{
+			Entry: entry,
+			Note:  &note,
+		}
+	}
+}
+
+func (c Client) ReferenceLink(p lit.Publication) string {
+	if doi := p.Values[KeyDOI]; doi != "" {
+		return "https://doi.org/" + doi
+	}
+	if ee := p.Values[KeyLinkEE]; ee != "" {
+		return ee
+	}
+	return p.Values[KeyLinkAbstract]
+}
+
+// NewClient returns a client for the DBLP publication search API. As DBLP
+// does not provide abstracts, the client has none: use WithAbstracts to
+// get an AbstractClient looking them up in another library.
+func NewClient() Client {
+	tr := &http.Transport{
+		MaxIdleConns:       10,
+		IdleConnTimeout:    15 * time.Second,
+		DisableCompression: false,
+	}
+
+	return Client{
+		endpoint:   endpoint,
+		httpClient: &http.Client{Transport: tr},
+	}
+}
@@ -0,0 +1,323 @@
+package dblp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/littest"
+)
+
+// abstracts is a fallback library only able to provide abstracts.
+type abstracts struct {
+	lit.Library
+	byDOI map[string]string
+}
+
+func (a abstracts) GetName() string {
+	return "abstracts"
+}
+
+func (a abstracts) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	text, ok := a.byDOI[p.Values[lit.KeyDOI]]
+	if !ok {
+		return lit.Abstract{}, fmt.Errorf("not found")
+	}
+	return lit.Abstract{Text: text}, nil
+}
+
+func newTestClient(t *testing.T, h http.HandlerFunc) Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c := NewClient()
+	c.endpoint = srv.URL
+	return c
+}
+
+func TestGetLiterature(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		want := map[string]string{
+			"q":      "fpga cnn",
+			"format": "json",
+			"h":      "30",
+			"f":      "60",
+		}
+		for k, v := range want {
+			if have := q.Get(k); have != v {
+				t.Errorf("%s: have %q, want %q", k, have, v)
+			}
+		}
+		fmt.Fprint(w, searchPage)
+	})
+
+	resp, err := c.GetLiterature(context.Background(), lit.Request{
+		Query:   "fpga cnn",
+		Page:    2,
+		PerPage: 30,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 3 {
+		t.Fatalf("blobs: have %d, want 3", resp.Len())
+	}
+
+	tt := []struct {
+		title     string
+		creator   string
+		key       string
+		docType   lit.DocumentType
+		entryType bibtex.EntryType
+		field     string
+		value     string
+	}{
+		{
+			title:     "Can FPGAs Beat GPUs in Accelerating Next-Generation Deep Neural Networks?",
+			creator:   "Eriko Nurvitadhi",
+			key:       "conf/fpga/NurvitadhiVSMLL17",
+			docType:   lit.TypeConferencePaper,
+			entryType: bibtex.EntryTypeInProceedings,
+			field:     "booktitle",
+			value:     "FPGA",
+		},
+		{
+			title:     "Angel-Eye: A Complete Design Flow for Mapping CNN Onto Embedded FPGA",
+			creator:   "Kaiyuan Guo",
+			key:       "journals/tcad/GuoSQYWYWY18",
+			docType:   lit.TypeArticle,
+			entryType: bibtex.EntryTypeArticle,
+			field:     "journal",
+			value:     "IEEE Trans. Comput. Aided Des. Integr. Circuits Syst.",
+		},
+		{
+			title:     "A Survey of FPGA-based Neural Network Accelerator",
+			creator:   "Kaiyuan Guo",
+			key:       "journals/corr/abs-1712-08934",
+			docType:   lit.TypePreprint,
+			entryType: bibtex.EntryTypeMisc,
+			field:     "note",
+			value:     "CoRR, DBLP: journals/corr/abs-1712-08934",
+		},
+	}
+	for i, v := range tt {
+		p, err := c.ParsePublication(resp.Blobs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Title != v.title {
+			t.Errorf("blob %d: title: have %q, want %q", i, p.Title, v.title)
+		}
+		if p.Creator != v.creator {
+			t.Errorf("blob %d: creator: have %q, want %q", i, p.Creator, v.creator)
+		}
+		if p.Values[KeyDBLPKey] != v.key {
+			t.Errorf("blob %d: key: have %q, want %q", i, p.Values[KeyDBLPKey], v.key)
+		}
+		if p.Type != v.docType {
+			t.Errorf("blob %d: type: have %q, want %q", i, p.Type, v.docType)
+		}
+		ref := c.ToBibTeX(p)
+		if ref.EntryType() != v.entryType {
+			t.Errorf("blob %d: entry type: have %q, want %q", i, ref.EntryType(), v.entryType)
+		}
+		if have := ref.Fields()[v.field]; have != v.value {
+			t.Errorf("blob %d: %s: have %q, want %q", i, v.field, have, v.value)
+		}
+	}
+}
+
+func TestGetMaxLiterature(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, searchPage)
+	})
+	max, err := c.GetMaxLiterature(context.Background(), lit.Request{Query: "fpga"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if max != 442 {
+		t.Fatalf("max literature: have %d, want 442", max)
+	}
+}
+
+func TestSearchWindow(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if h := r.URL.Query().Get("h"); h != "0" && h != "550" {
+			t.Errorf("h: have %q, want 550", h)
+		}
+		fmt.Fprint(w, strings.Replace(searchPage, `"@total": "442"`, `"@total": "25112"`, 1))
+	})
+	ctx := context.Background()
+	if max, err := c.GetMaxLiterature(ctx, lit.Request{Query: "fpga"}); err != nil || max != 10000 {
+		t.Fatalf("have %d results, %v, want 10000", max, err)
+	}
+	// The last page is cut at the window edge.
+	if _, err := c.GetLiterature(ctx, lit.Request{Query: "fpga", Page: 9, PerPage: 1050}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetLiterature(ctx, lit.Request{Query: "fpga", Page: 10, PerPage: 1000}); err == nil {
+		t.Fatal("expected an error past the search window")
+	}
+}
+
+func TestGetAbstractFallback(t *testing.T) {
+	fallback := abstracts{byDOI: map[string]string{
+		"10.1145/3020078.3021740": "GPUs are the norm.",
+	}}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, searchPage)
+	}).WithAbstracts(fallback)
+	resp, err := c.GetLiterature(context.Background(), lit.Request{Query: "fpga", PerPage: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	abs, err := c.GetAbstract(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if abs.Text != "GPUs are the norm." {
+		t.Fatalf("abstract: have %q", abs.Text)
+	}
+
+	// The CoRR entry has no DOI.
+	p, err = c.ParsePublication(resp.Blobs[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAbstract(context.Background(), p); err == nil {
+		t.Fatal("expected an error without a DOI")
+	}
+
+	if _, err := NewClient().WithAbstracts(nil).GetAbstract(context.Background(), p); !errors.Is(err, lit.ErrUnsupported) {
+		t.Fatalf("have %v without a fallback, want %v", err, lit.ErrUnsupported)
+	}
+	var ap lit.AbstractProvider
+	if lit.As(NewClient(), &ap) {
+		t.Fatal("a client without fallback provides abstracts")
+	}
+}
+
+func TestConformance(t *testing.T) {
+	var page searchResults
+	if err := json.Unmarshal([]byte(searchPage), &page); err != nil {
+		t.Fatal(err)
+	}
+	fixture := make([]json.RawMessage, len(page.Result.Hits.Hit))
+	for i, v := range page.Result.Hits.Hit {
+		fixture[i] = v.Info
+	}
+	infos := littest.Records(42, fixture, func(i int, info map[string]interface{}) {
+		info["key"] = fmt.Sprintf("conf/fpga/Nurvitadhi%d", i)
+		info["doi"] = fmt.Sprintf("10.1145/3020078.%d", i)
+		info["title"] = fmt.Sprintf("%s, part %d", info["title"], i)
+	})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		f, _ := strconv.Atoi(q.Get("f"))
+		h, _ := strconv.Atoi(q.Get("h"))
+		hits := []map[string]interface{}{}
+		for _, v := range littest.Page(infos, f, h) {
+			hits = append(hits, map[string]interface{}{"info": v})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result": map[string]interface{}{
+				"status": map[string]string{"@code": "200", "text": "OK"},
+				"hits": map[string]interface{}{
+					"@total": fmt.Sprintf("%d", len(infos)),
+					"hit":    hits,
+				},
+			},
+		})
+	})
+	littest.RunConformance(t, c, littest.Fixture{Query: "fpga", PerPage: 10, Max: 42})
+}
+
+// Trimmed down response recorded from
+// https://dblp.org/search/publ/api?q=fpga+cnn&format=json
+const searchPage = `{
+  "result": {
+    "query": "fpga cnn*",
+    "status": {"@code": "200", "text": "OK"},
+    "time": {"@unit": "msecs", "text": "12.34"},
+    "completions": {"@total": "1", "@computed": "1", "@sent": "1", "c": {"@sc": "442", "@dc": "442", "@oc": "442", "@id": "1", "text": "cnn"}},
+    "hits": {
+      "@total": "442",
+      "@computed": "442",
+      "@sent": "3",
+      "@first": "60",
+      "hit": [
+        {
+          "@score": "5",
+          "@id": "1",
+          "info": {
+            "authors": {"author": [
+              {"@pid": "17/1", "text": "Eriko Nurvitadhi"},
+              {"@pid": "17/2", "text": "Ganesh Venkatesh 0001"}
+            ]},
+            "title": "Can FPGAs Beat GPUs in Accelerating Next-Generation Deep Neural Networks?",
+            "venue": "FPGA",
+            "pages": "5-14",
+            "year": "2017",
+            "type": "Conference and Workshop Papers",
+            "access": "closed",
+            "key": "conf/fpga/NurvitadhiVSMLL17",
+            "doi": "10.1145/3020078.3021740",
+            "ee": "https://doi.org/10.1145/3020078.3021740",
+            "url": "https://dblp.org/rec/conf/fpga/NurvitadhiVSMLL17"
+          },
+          "url": "URL#1"
+        },
+        {
+          "@score": "5",
+          "@id": "2",
+          "info": {
+            "authors": {"author": {"@pid": "20/1", "text": "Kaiyuan Guo"}},
+            "title": "Angel-Eye: A Complete Design Flow for Mapping CNN Onto Embedded FPGA.",
+            "venue": "IEEE Trans. Comput. Aided Des. Integr. Circuits Syst.",
+            "volume": "37",
+            "number": "1",
+            "pages": "35-47",
+            "year": "2018",
+            "type": "Journal Articles",
+            "key": "journals/tcad/GuoSQYWYWY18",
+            "doi": "10.1109/TCAD.2017.2705069",
+            "ee": ["https://doi.org/10.1109/TCAD.2017.2705069", "https://www.wikidata.org/entity/Q1"],
+            "url": "https://dblp.org/rec/journals/tcad/GuoSQYWYWY18"
+          },
+          "url": "URL#2"
+        },
+        {
+          "@score": "4",
+          "@id": "3",
+          "info": {
+            "authors": {"author": [{"@pid": "20/1", "text": "Kaiyuan Guo"}]},
+            "title": "A Survey of FPGA-based Neural Network Accelerator.",
+            "venue": "CoRR",
+            "volume": "abs/1712.08934",
+            "year": "2017",
+            "type": "Informal and Other Publications",
+            "access": "open",
+            "key": "journals/corr/abs-1712-08934",
+            "ee": "http://arxiv.org/abs/1712.08934",
+            "url": "https://dblp.org/rec/journals/corr/abs-1712-08934"
+          },
+          "url": "URL#3"
+        }
+      ]
+    }
+  }
+}`
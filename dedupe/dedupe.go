@@ -0,0 +1,358 @@
+// Package dedupe finds publications describing the same work, possibly
+// coming from different libraries. Publications are first clustered by
+// DOI, then by title similarity among those published within a year of
+// each other by the same first author.
+package dedupe
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jecoz/lit"
+)
+
+// Reasons behind a Decision.
+const (
+	ReasonDOI   = "doi"
+	ReasonTitle = "title"
+)
+
+// DefaultThreshold is the title similarity above which two publications
+// are considered the same work.
+const DefaultThreshold = 0.9
+
+// Decision tells that the publication at index Duplicate describes the
+// same work as the one at index Kept, which has the richest metadata of
+// the two.
+type Decision struct {
+	Kept      int
+	Duplicate int
+	Reason    string
+	// Score is the title similarity, 1 for DOI matches.
+	Score float64
+}
+
+func (d Decision) String() string {
+	return fmt.Sprintf("%d duplicate of %d (%s, %.2f)", d.Duplicate, d.Kept, d.Reason, d.Score)
+}
+
+// NormalizeDOI lower cases doi, dropping resolver prefixes.
+func NormalizeDOI(doi string) string {
+	doi = strings.ToLower(strings.TrimSpace(doi))
+	for _, p := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		doi = strings.TrimPrefix(doi, p)
+	}
+	return strings.TrimSpace(doi)
+}
+
+// NormalizeTitle lower cases title, keeping letters and digits only.
+func NormalizeTitle(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+func bigrams(s string) map[string]int {
+	runes := []rune(s)
+	m := make(map[string]int, len(runes))
+	for i := 0; i+1 < len(runes); i++ {
+		m[string(runes[i:i+2])]++
+	}
+	return m
+}
+
+func dice(a, b map[string]int) float64 {
+	na, nb, common := 0, 0, 0
+	for k, v := range a {
+		na += v
+		if w, ok := b[k]; ok {
+			if w < v {
+				common += w
+			} else {
+				common += v
+			}
+		}
+	}
+	for _, v := range b {
+		nb += v
+	}
+	if na+nb == 0 {
+		return 0
+	}
+	return 2 * float64(common) / float64(na+nb)
+}
+
+// TitleSimilarity returns the Sørensen–Dice coefficient of the character
+// bigrams of the normalized titles, robust to punctuation, casing and
+// small typos.
+func TitleSimilarity(a, b string) float64 {
+	return dice(bigrams(NormalizeTitle(a)), bigrams(NormalizeTitle(b)))
+}
+
+// authorNames returns the words of a creator that are not initials, as
+// libraries format names differently: "Nurvitadhi E.", "Eriko
+// Nurvitadhi" and "Nurvitadhi, Eriko" all share "nurvitadhi".
+func authorNames(creator string) map[string]bool {
+	names := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(creator), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		if len([]rune(w)) > 1 {
+			names[w] = true
+		}
+	}
+	return names
+}
+
+// firstAuthor returns the name of the first author of p, its creator
+// when the library did not list them.
+func firstAuthor(p lit.Publication) string {
+	if len(p.Authors) > 0 {
+		return p.Authors[0].Name
+	}
+	return p.Creator
+}
+
+func sameAuthor(a, b map[string]bool) bool {
+	// Missing authors do not rule out a match.
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for k := range a {
+		if b[k] {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedKeys are the Values keys with the same meaning in every library.
+var sharedKeys = []string{
+	lit.KeyDOI,
+	lit.KeyIssn,
+	lit.KeyAuthors,
+	lit.KeyPublisher,
+	lit.KeyPublicationName,
+	lit.KeyVolume,
+	lit.KeyIssue,
+	lit.KeyPageRange,
+}
+
+// Richness scores the metadata of p: shared values, typed metadata and
+// the abstract count the most, as they survive a Merge.
+func Richness(p lit.Publication) int {
+	score := 0
+	for _, k := range sharedKeys {
+		if p.Values[k] != "" {
+			score += 10
+		}
+	}
+	for _, ok := range []bool{
+		p.IDs.DOI != "",
+		len(p.Authors) > 0,
+		p.Venue != "",
+		p.Volume != "",
+		p.Issue != "",
+		p.Pages != "",
+		p.Type != "",
+		p.CitedBy != nil,
+	} {
+		if ok {
+			score += 10
+		}
+	}
+	if p.Abstract != nil && p.Abstract.Text != "" {
+		score += 20
+	}
+	for _, v := range p.Values {
+		if v != "" {
+			score++
+		}
+	}
+	return score
+}
+
+type item struct {
+	index   int
+	doi     string
+	year    int
+	bigrams map[string]int
+	authors map[string]bool
+}
+
+// unionFind keeps track of the clusters found so far.
+type unionFind []int
+
+func (u unionFind) find(i int) int {
+	for u[i] != i {
+		u[i] = u[u[i]]
+		i = u[i]
+	}
+	return i
+}
+
+func (u unionFind) union(i, j int) {
+	u[u.find(i)] = u.find(j)
+}
+
+// Find clusters pubs, skipping the indices in skip, and returns a
+// decision for each publication that is not the richest of its cluster.
+// Publications with no title similarity above threshold are left alone.
+func Find(pubs []lit.Publication, skip map[int]bool, threshold float64) []Decision {
+	items := []item{}
+	for i, p := range pubs {
+		if skip[i] {
+			continue
+		}
+		items = append(items, item{
+			index:   i,
+			doi:     NormalizeDOI(p.DOI()),
+			year:    p.CoverDate.Year(),
+			bigrams: bigrams(NormalizeTitle(p.Title)),
+			authors: authorNames(firstAuthor(p)),
+		})
+	}
+
+	clusters := make(unionFind, len(pubs))
+	for i := range clusters {
+		clusters[i] = i
+	}
+	// dois holds the DOI of each cluster root, if any: clusters with
+	// different DOIs are different works, no matter their titles.
+	dois := make(map[int]string)
+	reasons := make(map[[2]int]Decision)
+	link := func(a, b item, reason string, score float64) {
+		ra, rb := clusters.find(a.index), clusters.find(b.index)
+		if ra == rb {
+			return
+		}
+		da, db := dois[ra], dois[rb]
+		if da != "" && db != "" && da != db {
+			return
+		}
+		clusters.union(a.index, b.index)
+		if da == "" {
+			da = db
+		}
+		dois[clusters.find(a.index)] = da
+		reasons[[2]int{a.index, b.index}] = Decision{Reason: reason, Score: score}
+		reasons[[2]int{b.index, a.index}] = Decision{Reason: reason, Score: score}
+	}
+
+	// DOIs first.
+	byDOI := make(map[string]item)
+	for _, v := range items {
+		dois[v.index] = v.doi
+		if v.doi == "" {
+			continue
+		}
+		if first, ok := byDOI[v.doi]; ok {
+			link(first, v, ReasonDOI, 1)
+			continue
+		}
+		byDOI[v.doi] = v
+	}
+
+	// Then titles, comparing only publications from close years.
+	byYear := make(map[int][]item)
+	for _, v := range items {
+		byYear[v.year] = append(byYear[v.year], v)
+	}
+	for _, a := range items {
+		for _, year := range []int{a.year, a.year + 1} {
+			for _, b := range byYear[year] {
+				if b.index <= a.index && year == a.year {
+					continue
+				}
+				if !sameAuthor(a.authors, b.authors) {
+					continue
+				}
+				if score := dice(a.bigrams, b.bigrams); score >= threshold {
+					link(a, b, ReasonTitle, score)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for _, v := range items {
+		root := clusters.find(v.index)
+		members[root] = append(members[root], v.index)
+	}
+	roots := make([]int, 0, len(members))
+	for k := range members {
+		roots = append(roots, k)
+	}
+	sort.Ints(roots)
+
+	decisions := []Decision{}
+	for _, root := range roots {
+		m := members[root]
+		if len(m) < 2 {
+			continue
+		}
+		kept := m[0]
+		for _, v := range m[1:] {
+			if Richness(pubs[v]) > Richness(pubs[kept]) {
+				kept = v
+			}
+		}
+		for _, v := range m {
+			if v == kept {
+				continue
+			}
+			d, ok := reasons[[2]int{kept, v}]
+			if !ok {
+				// Linked through another member of the cluster.
+				d = bestReason(reasons, m, v)
+			}
+			d.Kept, d.Duplicate = kept, v
+			decisions = append(decisions, d)
+		}
+	}
+	return decisions
+}
+
+func bestReason(reasons map[[2]int]Decision, members []int, v int) Decision {
+	best := Decision{Reason: ReasonTitle}
+	for _, w := range members {
+		d, ok := reasons[[2]int{v, w}]
+		if !ok {
+			continue
+		}
+		if d.Reason == ReasonDOI || d.Score > best.Score {
+			best = d
+		}
+		if d.Reason == ReasonDOI {
+			break
+		}
+	}
+	return best
+}
+
+// Merge fills the shared values, typed metadata and abstract missing
+// from kept with the ones of its duplicates. Library specific values are
+// left alone, as they only make sense to the library that produced them.
+func Merge(kept lit.Publication, duplicates ...lit.Publication) lit.Publication {
+	values := make(map[string]string, len(kept.Values))
+	for k, v := range kept.Values {
+		values[k] = v
+	}
+	for _, d := range duplicates {
+		for _, k := range sharedKeys {
+			if values[k] == "" && d.Values[k] != "" {
+				values[k] = d.Values[k]
+			}
+		}
+		kept.Complete(d)
+		if kept.Abstract == nil && d.Abstract != nil {
+			abs := *d.Abstract
+			kept.Abstract = &abs
+		}
+	}
+	kept.Values = values
+	return kept
+}
@@ -0,0 +1,136 @@
+package dedupe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jecoz/lit"
+)
+
+// pub returns a publication with the typed metadata set from values, as
+// libraries do.
+func pub(title, creator string, year int, values map[string]string) lit.Publication {
+	return lit.Publication{
+		Title:     title,
+		Creator:   creator,
+		CoverDate: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		IDs:       lit.Identifiers{DOI: values[lit.KeyDOI]},
+		Venue:     values[lit.KeyPublicationName],
+		Pages:     values[lit.KeyPageRange],
+		Values:    values,
+	}
+}
+
+func TestNormalizeDOI(t *testing.T) {
+	for _, v := range []string{
+		"10.1145/3020078.3021740",
+		"10.1145/3020078.3021740 ",
+		"https://doi.org/10.1145/3020078.3021740",
+		"http://dx.doi.org/10.1145/3020078.3021740",
+		"doi:10.1145/3020078.3021740",
+		"10.1145/3020078.3021740",
+	} {
+		if have, want := NormalizeDOI(v), "10.1145/3020078.3021740"; have != want {
+			t.Errorf("%q: have %q, want %q", v, have, want)
+		}
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	tt := []struct {
+		a, b string
+		same bool
+	}{
+		{"Can FPGAs beat GPUs in accelerating next-generation deep neural networks?", "Can FPGAs Beat GPUs in Accelerating Next-Generation Deep Neural Networks", true},
+		{"Can FPGAs beat GPUs in accelerating next-generation deep neural networks?", "Can FPGAs beat GPUs in acelerating next generation deep neural networks", true},
+		{"Can FPGAs beat GPUs in accelerating next-generation deep neural networks?", "Accelerating deep neural networks on FPGAs: a survey", false},
+		{"", "", false},
+	}
+	for _, v := range tt {
+		if have := TitleSimilarity(v.a, v.b) >= DefaultThreshold; have != v.same {
+			t.Errorf("%q ~ %q (%.2f): have %v, want %v", v.a, v.b, TitleSimilarity(v.a, v.b), have, v.same)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	title := "Can FPGAs beat GPUs in accelerating next-generation deep neural networks?"
+	pubs := []lit.Publication{
+		// 0: scopus, no abstract.
+		pub(title, "Nurvitadhi E.", 2017, map[string]string{
+			lit.KeyDOI: "10.1145/3020078.3021740",
+			"eid":      "2-s2.0-85016025377",
+		}),
+		// 1: openalex, richer.
+		pub("Can FPGAs Beat GPUs in Accelerating Next-Generation Deep Neural Networks?", "Eriko Nurvitadhi", 2017, map[string]string{
+			lit.KeyDOI:             "https://doi.org/10.1145/3020078.3021740",
+			lit.KeyPublicationName: "FPGA '17",
+			lit.KeyPageRange:       "5-14",
+		}),
+		// 2: dblp, no DOI, title match.
+		pub("Can FPGAs Beat GPUs in Accelerating Next-Generation Deep Neural Networks", "Eriko Nurvitadhi", 2017, nil),
+		// 3: same title, different author: not a duplicate.
+		pub(title, "Someone Else", 2017, nil),
+		// 4: same title, years apart: not a duplicate.
+		pub(title, "Nurvitadhi E.", 2012, nil),
+		// 5: unrelated.
+		pub("Accelerating deep neural networks on FPGAs: a survey", "Nurvitadhi E.", 2017, nil),
+		// 6: same title, different DOI: not a duplicate.
+		pub(title, "Nurvitadhi E.", 2017, map[string]string{
+			lit.KeyDOI: "10.1109/other",
+		}),
+	}
+	ds := Find(pubs, nil, DefaultThreshold)
+	if len(ds) != 2 {
+		t.Fatalf("have %d decisions, want 2: %v", len(ds), ds)
+	}
+	want := map[int]string{0: ReasonDOI, 2: ReasonTitle}
+	for _, d := range ds {
+		if d.Kept != 1 {
+			t.Errorf("%v: have kept %d, want 1", d, d.Kept)
+		}
+		if reason, ok := want[d.Duplicate]; !ok || reason != d.Reason {
+			t.Errorf("unexpected decision %v", d)
+		}
+	}
+
+	// Skipped publications do not take part in clustering.
+	if ds := Find(pubs, map[int]bool{1: true, 2: true}, DefaultThreshold); len(ds) != 0 {
+		t.Errorf("have %v, want no decisions", ds)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	kept := pub("Title", "Author", 2017, map[string]string{
+		lit.KeyDOI: "10.1/x",
+		"eid":      "kept",
+	})
+	dup := pub("Title", "Author", 2017, map[string]string{
+		lit.KeyDOI:       "10.1/y",
+		lit.KeyPageRange: "5-14",
+		"eid":            "dup",
+	})
+	dup.Abstract = &lit.Abstract{Text: "abstract"}
+	dup.Authors = []lit.Author{{Name: "Author", ORCID: "0000-0002-1825-0097"}}
+	dup.Type = lit.TypeConferencePaper
+
+	m := Merge(kept, dup)
+	if have := m.Values[lit.KeyDOI]; have != "10.1/x" {
+		t.Errorf("doi: have %q, want the kept one", have)
+	}
+	if have := m.Values[lit.KeyPageRange]; have != "5-14" {
+		t.Errorf("page range: have %q, want 5-14", have)
+	}
+	if have := m.Values["eid"]; have != "kept" {
+		t.Errorf("library specific values must not be merged, have eid %q", have)
+	}
+	if m.IDs.DOI != "10.1/x" || m.Pages != "5-14" || m.Type != lit.TypeConferencePaper || len(m.Authors) != 1 {
+		t.Errorf("unexpected typed metadata %+v", m)
+	}
+	if m.Abstract == nil || m.Abstract.Text != "abstract" {
+		t.Errorf("abstract: have %v", m.Abstract)
+	}
+	if _, ok := kept.Values[lit.KeyPageRange]; ok {
+		t.Errorf("merge modified the kept publication values")
+	}
+}
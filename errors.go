@@ -0,0 +1,99 @@
+package lit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Errors classifying the failures of library requests. Errors returned by
+// libraries match them through errors.Is.
+var (
+	// ErrUnauthorized tells that the API key is missing or invalid.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotEntitled tells that the API key is valid, but not allowed to
+	// access the resource requested.
+	ErrNotEntitled = errors.New("not entitled")
+	// ErrQuotaExceeded tells that the API key used up its quota: retrying
+	// does not help until the quota is reset.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrRateLimited tells that requests are too frequent. The StatusError
+	// carries the delay asked by the library, if any.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrNotFound tells that the resource requested does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrMalformedResponse tells that the response of the library could
+	// not be decoded.
+	ErrMalformedResponse = errors.New("malformed response")
+	// ErrUnsupported tells that the library lacks the capability
+	// requested, e.g. it is not an AbstractProvider.
+	ErrUnsupported = errors.New("not supported by the library")
+)
+
+// statusKind returns the error class of an HTTP status code, nil when
+// there is none.
+func statusKind(code int) error {
+	switch code {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrNotEntitled
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+type malformedError struct {
+	err error
+}
+
+func (e *malformedError) Error() string        { return "malformed response: " + e.err.Error() }
+func (e *malformedError) Unwrap() error        { return e.err }
+func (e *malformedError) Is(target error) bool { return target == ErrMalformedResponse }
+
+// Malformed wraps err, returned decoding the response of a library, so
+// that it matches ErrMalformedResponse.
+func Malformed(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &malformedError{err: err}
+}
+
+// Hint returns what the user can do about err, the empty string when err
+// does not belong to any of the classes above.
+func Hint(err error) string {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return "API key missing or invalid"
+	case errors.Is(err, ErrNotEntitled):
+		return "API key lacks entitlement"
+	case errors.Is(err, ErrQuotaExceeded):
+		return "API key quota exhausted, wait for it to reset"
+	case errors.Is(err, ErrRateLimited):
+		var se *StatusError
+		if errors.As(err, &se) && se.RetryAfter > 0 {
+			return fmt.Sprintf("rate limited, retry in %v", se.RetryAfter.Round(time.Second))
+		}
+		return "rate limited, slow down"
+	case errors.Is(err, ErrNotFound):
+		return "not found"
+	case errors.Is(err, ErrMalformedResponse):
+		return "unexpected response, the API might have changed"
+	default:
+		return ""
+	}
+}
+
+// Describe returns err prefixed by its hint, if any.
+func Describe(err error) string {
+	if h := Hint(err); h != "" {
+		return h + ": " + err.Error()
+	}
+	return err.Error()
+}
@@ -0,0 +1,20 @@
+package lit
+
+import "context"
+
+// FacetYear is the field breaking results down by year of publication.
+const FacetYear = "year"
+
+// Facet is the number of results sharing a value of a field.
+type Facet struct {
+	Value string
+	Count int
+}
+
+// FacetProvider is implemented by libraries breaking down the results of
+// a query by the values of a field, such as FacetYear, in a single
+// request. Facets are sorted by value. Fields the library does not know
+// fail with ErrUnsupported.
+type FacetProvider interface {
+	Facets(ctx context.Context, r Request, field string) ([]Facet, error)
+}
@@ -0,0 +1,495 @@
+// Package filelib exposes a reference list stored in a local .bib or .ris
+// file, e.g. exported from Zotero or Google Scholar, as a lit.Library.
+// Queries are evaluated locally with the query package against title,
+// abstract and keywords of each entry.
+package filelib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/query"
+)
+
+const (
+	FormatBibTeX = "bibtex"
+	FormatRIS    = "ris"
+)
+
+const (
+	KeyCiteKey         = "cite_key"
+	KeyEntryType       = "entry_type"
+	KeyFormat          = "format"
+	KeyLinkAbstract    = lit.KeyLinkAbstract
+	KeySourceKeywords  = "source_keywords"
+	KeyAddress         = "address"
+	KeyNote            = "note"
+	KeyInstitution     = "institution"
+	KeyDOI             = lit.KeyDOI
+	KeyIssn            = lit.KeyIssn
+	KeyAuthors         = lit.KeyAuthors
+	KeyPublisher       = lit.KeyPublisher
+	KeyPublicationName = lit.KeyPublicationName
+	KeyVolume          = lit.KeyVolume
+	KeyIssue           = lit.KeyIssue
+	KeyPageRange       = lit.KeyPageRange
+)
+
+// entry is the blob format shared by both file formats, using BibTeX
+// field names.
+type entry struct {
+	Format string            `json:"format"`
+	Type   bibtex.EntryType  `json:"type"`
+	Key    string            `json:"key,omitempty"`
+	Fields map[string]string `json:"fields"`
+}
+
+func (e entry) field(name string) string {
+	return bibtex.Clean(e.Fields[name])
+}
+
+// Text implements query.Document. Besides the default title, abstract and
+// keywords, the Scopus field names TITLE, ABS, KEY, TITLE-ABS-KEY,
+// TITLE-ABS and AUTHOR are understood. Any other field name is looked up
+// among the BibTeX fields of the entry.
+func (e entry) Text(field string) string {
+	switch field {
+	case "", "TITLE-ABS-KEY", "ALL":
+		return strings.Join([]string{e.field("title"), e.field("abstract"), e.field("keywords")}, " ")
+	case "TITLE-ABS":
+		return e.field("title") + " " + e.field("abstract")
+	case "TITLE":
+		return e.field("title")
+	case "ABS", "ABSTRACT":
+		return e.field("abstract")
+	case "KEY", "KEYWORDS", "AUTHKEY":
+		return e.field("keywords")
+	case "AUTHOR", "AUTH":
+		return e.field("author")
+	default:
+		return e.field(strings.ToLower(field))
+	}
+}
+
+func fromBibTeX(r bibtex.Record) entry {
+	return entry{
+		Format: FormatBibTeX,
+		Type:   r.Type,
+		Key:    r.Key,
+		Fields: r.Fields,
+	}
+}
+
+var risTypes = map[string]bibtex.EntryType{
+	"JOUR":   bibtex.EntryTypeArticle,
+	"JFULL":  bibtex.EntryTypeArticle,
+	"EJOUR":  bibtex.EntryTypeArticle,
+	"MGZN":   bibtex.EntryTypeArticle,
+	"NEWS":   bibtex.EntryTypeArticle,
+	"CONF":   bibtex.EntryTypeInProceedings,
+	"CPAPER": bibtex.EntryTypeInProceedings,
+	"CHAP":   bibtex.EntryTypeInCollection,
+	"BOOK":   bibtex.EntryTypeBook,
+	"EBOOK":  bibtex.EntryTypeBook,
+	"EDBOOK": bibtex.EntryTypeBook,
+	"RPRT":   bibtex.EntryTypeTechReport,
+	"THES":   bibtex.EntryTypePhDThesis,
+}
+
+var year = regexp.MustCompile(`\d{4}`)
+
+func fromRIS(r risRecord) entry {
+	typ, ok := risTypes[r.first("TY")]
+	if !ok {
+		typ = bibtex.EntryTypeMisc
+	}
+	venue := "journal"
+	if typ == bibtex.EntryTypeInProceedings || typ == bibtex.EntryTypeInCollection {
+		venue = "booktitle"
+	}
+
+	authors := append(append([]string{}, r["AU"]...), r["A1"]...)
+	pages := r.first("SP")
+	if end := r.first("EP"); end != "" && pages != "" {
+		pages += "-" + end
+	}
+	date := r.first("PY", "Y1", "DA")
+
+	fields := map[string]string{
+		"title":     r.first("TI", "T1", "CT"),
+		"author":    strings.Join(authors, " and "),
+		"year":      year.FindString(date),
+		"abstract":  r.first("AB", "N2"),
+		"keywords":  strings.Join(r["KW"], ", "),
+		"doi":       r.first("DO"),
+		"issn":      r.first("SN"),
+		"url":       r.first("UR", "L2"),
+		venue:       r.first("T2", "JO", "JF", "J2", "BT"),
+		"volume":    r.first("VL"),
+		"number":    r.first("IS"),
+		"pages":     pages,
+		"publisher": r.first("PB"),
+		"address":   r.first("CY"),
+		"note":      r.first("N1"),
+		"language":  r.first("LA"),
+	}
+	if typ == bibtex.EntryTypeTechReport {
+		fields["institution"] = fields["publisher"]
+	}
+	for k, v := range fields {
+		if v == "" {
+			delete(fields, k)
+		}
+	}
+	return entry{
+		Format: FormatRIS,
+		Type:   typ,
+		Key:    r.first("ID"),
+		Fields: fields,
+	}
+}
+
+type Client struct {
+	path string
+}
+
+// load reads the file each time it is called, so that changes are picked
+// up between runs without caching anything.
+func (c Client) load() ([]entry, error) {
+	b, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var format string
+	switch strings.ToLower(filepath.Ext(c.path)) {
+	case ".bib", ".bibtex":
+		format = FormatBibTeX
+	case ".ris":
+		format = FormatRIS
+	default:
+		format = FormatBibTeX
+		if risLine.Match(bytes.TrimSpace(bytes.SplitN(b, []byte("\n"), 2)[0])) {
+			format = FormatRIS
+		}
+	}
+
+	entries := []entry{}
+	switch format {
+	case FormatRIS:
+		records, err := parseRIS(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.path, err)
+		}
+		for _, v := range records {
+			entries = append(entries, fromRIS(v))
+		}
+	default:
+		records, err := bibtex.Parse(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.path, err)
+		}
+		for _, v := range records {
+			entries = append(entries, fromBibTeX(v))
+		}
+	}
+	return entries, nil
+}
+
+func (c Client) search(ctx context.Context, req lit.Request) ([]entry, error) {
+	// Reading the file is quick enough not to be interrupted.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	expr, err := query.Parse(req.Query)
+	if err != nil {
+		return nil, fmt.Errorf("parse query: %w", err)
+	}
+	entries, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	matches := []entry{}
+	for _, v := range entries {
+		if expr.Match(v) {
+			matches = append(matches, v)
+		}
+	}
+	return matches, nil
+}
+
+func (c Client) DefaultPerPage() int {
+	return 100
+}
+
+func (c Client) GetLiterature(ctx context.Context, req lit.Request) (lit.Response, error) {
+	matches, err := c.search(ctx, req)
+	if err != nil {
+		return lit.Response{}, err
+	}
+
+	from := req.Page * req.PerPage
+	to := from + req.PerPage
+	if from > len(matches) {
+		from = len(matches)
+	}
+	if to > len(matches) {
+		to = len(matches)
+	}
+
+	blobs := make([]lit.Blob, 0, to-from)
+	for _, v := range matches[from:to] {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return lit.Response{}, err
+		}
+		blobs = append(blobs, lit.Blob(b))
+	}
+	return lit.Response{
+		Req:   req,
+		Blobs: blobs,
+	}, nil
+}
+
+func (c Client) GetMaxLiterature(ctx context.Context, req lit.Request) (int, error) {
+	matches, err := c.search(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return len(matches), nil
+}
+
+func (c Client) GetRateLimit() time.Duration {
+	// Nothing to wait for, but a positive duration is still needed by
+	// the search loop.
+	return time.Millisecond
+}
+
+func (c Client) ConcurrencyLimit() int {
+	return 1
+}
+
+func (c Client) PrettyPrint(b lit.Blob, dst *bytes.Buffer) error {
+	return json.Indent(dst, []byte(b), "", "\t")
+}
+
+func (e entry) CoverDate() time.Time {
+	var y int
+	fmt.Sscanf(year.FindString(e.field("year")), "%d", &y)
+	if y == 0 {
+		// Seed lists are not always complete, undated entries are
+		// still worth reviewing.
+		return time.Time{}
+	}
+	month := time.January
+	if m, err := time.Parse("January", e.field("month")); err == nil {
+		month = m.Month()
+	} else if m, err := time.Parse("Jan", e.field("month")); err == nil {
+		month = m.Month()
+	}
+	return time.Date(y, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (e entry) Creator() string {
+	authors := strings.Split(e.field("author"), " and ")
+	return strings.TrimSpace(authors[0])
+}
+
+func (e entry) AuthorList() []lit.Author {
+	var authors []lit.Author
+	for _, v := range strings.Split(e.field("author"), " and ") {
+		if v = strings.TrimSpace(v); v != "" {
+			authors = append(authors, lit.Author{Name: v})
+		}
+	}
+	return authors
+}
+
+// KeywordList splits the keywords of the entry, separated by commas or
+// semicolons depending on the exporter.
+func (e entry) KeywordList() []string {
+	var keywords []string
+	for _, v := range strings.FieldsFunc(e.field("keywords"), func(r rune) bool { return r == ',' || r == ';' }) {
+		if v = strings.TrimSpace(v); v != "" {
+			keywords = append(keywords, v)
+		}
+	}
+	return keywords
+}
+
+// ArxivID returns the arXiv identifier of e-prints.
+func (e entry) ArxivID() string {
+	if strings.EqualFold(e.field("archiveprefix"), "arxiv") || strings.EqualFold(e.field("eprinttype"), "arxiv") {
+		return e.field("eprint")
+	}
+	return ""
+}
+
+// DocumentType maps the entry type onto the types of lit. Miscellaneous
+// e-prints are preprints.
+func (e entry) DocumentType() lit.DocumentType {
+	switch e.Type {
+	case bibtex.EntryTypeArticle:
+		return lit.TypeArticle
+	case bibtex.EntryTypeInProceedings, bibtex.EntryTypeConference:
+		return lit.TypeConferencePaper
+	case bibtex.EntryTypeInCollection, bibtex.EntryTypeInBook:
+		return lit.TypeBookChapter
+	case bibtex.EntryTypeBook:
+		return lit.TypeBook
+	case bibtex.EntryTypePhDThesis, bibtex.EntryTypeMasterThesis, "mastersthesis":
+		return lit.TypeThesis
+	case bibtex.EntryTypeTechReport:
+		return lit.TypeReport
+	case bibtex.EntryTypeUnpublished:
+		return lit.TypePreprint
+	}
+	if e.ArxivID() != "" {
+		return lit.TypePreprint
+	}
+	return lit.TypeOther
+}
+
+func (e entry) Values() map[string]string {
+	venue := e.field("journal")
+	if venue == "" {
+		venue = e.field("booktitle")
+	}
+	return map[string]string{
+		KeyCiteKey:         e.Key,
+		KeyEntryType:       string(e.Type),
+		KeyFormat:          e.Format,
+		KeyLinkAbstract:    e.field("url"),
+		KeySourceKeywords:  e.field("keywords"),
+		KeyAddress:         e.field("address"),
+		KeyNote:            e.field("note"),
+		KeyInstitution:     e.field("institution"),
+		KeyDOI:             e.field("doi"),
+		KeyIssn:            e.field("issn"),
+		KeyAuthors:         e.field("author"),
+		KeyPublisher:       e.field("publisher"),
+		KeyPublicationName: venue,
+		KeyVolume:          e.field("volume"),
+		KeyIssue:           e.field("number"),
+		KeyPageRange:       e.field("pages"),
+	}
+}
+
+func (c Client) ParsePublication(b lit.Blob) (lit.Publication, error) {
+	var e entry
+	if err := json.Unmarshal([]byte(b), &e); err != nil {
+		return lit.Publication{}, err
+	}
+	if e.field("title") == "" {
+		return lit.Publication{}, fmt.Errorf("entry %q has no title", e.Key)
+	}
+
+	values := e.Values()
+	p := lit.Publication{
+		Title:     e.field("title"),
+		CoverDate: e.CoverDate(),
+		Creator:   e.Creator(),
+		Authors:   e.AuthorList(),
+		IDs: lit.Identifiers{
+			DOI:   e.field("doi"),
+			PMID:  e.field("pmid"),
+			ArXiv: e.ArxivID(),
+			ISBN:  e.field("isbn"),
+		},
+		Venue:          values[KeyPublicationName],
+		Volume:         e.field("volume"),
+		Issue:          e.field("number"),
+		Pages:          e.field("pages"),
+		Type:           e.DocumentType(),
+		Language:       e.field("language"),
+		SourceKeywords: e.KeywordList(),
+		Values:         values,
+	}
+	if abs := e.field("abstract"); abs != "" {
+		p.Abstract = &lit.Abstract{Text: abs}
+	}
+	return p, nil
+}
+
+// GetName includes the file name, so that multiple files can be searched
+// together.
+func (c Client) GetName() string {
+	return "File " + filepath.Base(c.path)
+}
+
+// NativeID returns the key of the entry p comes from, unique within the file.
+func (c Client) NativeID(p lit.Publication) string {
+	return p.Values[KeyCiteKey]
+}
+
+func (c Client) ToBibTeX(p lit.Publication) bibtex.Reference {
+	entry := p.BibTeXEntry()
+	venue := p.Values[KeyPublicationName]
+
+	switch bibtex.EntryType(p.Values[KeyEntryType]) {
+	case bibtex.EntryTypeArticle:
+		return bibtex.Article{
+			Entry:     entry,
+			Journal:   venue,
+			Volume:    p.Value(KeyVolume),
+			Number:    p.Value(KeyIssue),
+			PageRange: p.Value(KeyPageRange),
+		}
+	case bibtex.EntryTypeInProceedings, bibtex.EntryTypeConference:
+		return bibtex.InProceedings{
+			Entry:     entry,
+			BookTitle: venue,
+			PageRange: p.Value(KeyPageRange),
+			Publisher: p.Value(KeyPublisher),
+			Address:   p.Value(KeyAddress),
+		}
+	case bibtex.EntryTypeInCollection, bibtex.EntryTypeInBook:
+		return bibtex.InCollection{
+			Entry:     entry,
+			BookTitle: venue,
+			Publisher: p.Values[KeyPublisher],
+			PageRange: p.Value(KeyPageRange),
+			Address:   p.Value(KeyAddress),
+		}
+	case bibtex.EntryTypeBook:
+		return bibtex.Book{
+			Entry:     entry,
+			Publisher: p.Values[KeyPublisher],
+			Address:   p.Value(KeyAddress),
+		}
+	case bibtex.EntryTypeTechReport:
+		return bibtex.TechReport{
+			Entry:       entry,
+			Institution: p.Values[KeyInstitution],
+			Number:      p.Value(KeyIssue),
+		}
+	default:
+		return bibtex.Misc{
+			Entry: entry,
+			Note:  p.Value(KeyNote),
+		}
+	}
+}
+
+func (c Client) ReferenceLink(p lit.Publication) string {
+	if doi := p.Values[KeyDOI]; doi != "" {
+		return "https://doi.org/" + doi
+	}
+	return p.Values[KeyLinkAbstract]
+}
+
+// NewClient returns a library serving the entries of the .bib or .ris
+// file at path. The format is detected from the extension, falling back
+// to sniffing the contents. The file is read on each search.
+func NewClient(path string) Client {
+	return Client{path: path}
+}
@@ -0,0 +1,140 @@
+package filelib
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/littest"
+)
+
+func TestBibTeX(t *testing.T) {
+	c := NewClient("testdata/seeds.bib")
+	ctx := context.Background()
+
+	max, err := c.GetMaxLiterature(ctx, lit.Request{Query: "*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if max != 3 {
+		t.Fatalf("max literature: have %d, want 3", max)
+	}
+
+	resp, err := c.GetLiterature(ctx, lit.Request{Query: "fpga AND (gpus OR cnn)", PerPage: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 2 {
+		t.Fatalf("blobs: have %d, want 2", resp.Len())
+	}
+
+	p, err := c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Can FPGAs Beat GPUs in Accelerating Next-Generation Deep Neural Networks?"; p.Title != want {
+		t.Fatalf("title: have %q, want %q", p.Title, want)
+	}
+	if p.CoverDate.Year() != 2017 || p.CoverDate.Month() != 2 {
+		t.Fatalf("cover date: have %v", p.CoverDate)
+	}
+	if want := "Nurvitadhi, Eriko"; p.Creator != want {
+		t.Fatalf("creator: have %q, want %q", p.Creator, want)
+	}
+	if p.Abstract == nil {
+		t.Fatal("abstract not found")
+	}
+	ref := c.ToBibTeX(p)
+	if ref.EntryType() != bibtex.EntryTypeInProceedings || ref.Fields()["pages"] != "5-14" {
+		t.Fatalf("unexpected reference: %v %v", ref.EntryType(), ref.Fields())
+	}
+	if len(p.Authors) != 2 || p.Authors[1].Name != "Venkatesh, Ganesh" || p.IDs.DOI != "10.1145/3020078.3021740" || p.Type != lit.TypeConferencePaper {
+		t.Fatalf("unexpected metadata %+v, %+v, %s", p.Authors, p.IDs, p.Type)
+	}
+	if fmt.Sprint(p.SourceKeywords) != "[FPGA deep learning]" {
+		t.Fatalf("keywords: have %q", p.SourceKeywords)
+	}
+
+	// Macros and concatenation.
+	resp, err = c.GetLiterature(ctx, lit.Request{Query: "TITLE(convolutional)", PerPage: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 1 {
+		t.Fatalf("blobs: have %d, want 1", resp.Len())
+	}
+	p, err = c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "International Conference on Field Programmable Logic and Applications (FPL)"; p.Values[KeyPublicationName] != want {
+		t.Fatalf("booktitle: have %q, want %q", p.Values[KeyPublicationName], want)
+	}
+}
+
+func TestRIS(t *testing.T) {
+	c := NewClient("testdata/seeds.ris")
+	resp, err := c.GetLiterature(context.Background(), lit.Request{Query: "*", PerPage: 1, Page: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 1 {
+		t.Fatalf("blobs: have %d, want 1", resp.Len())
+	}
+	p, err := c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref := c.ToBibTeX(p); ref.EntryType() != bibtex.EntryTypeInCollection || ref.Fields()["publisher"] != "Example Press" {
+		t.Fatalf("unexpected reference: %v %v", ref.EntryType(), ref.Fields())
+	}
+
+	resp, err = c.GetLiterature(context.Background(), lit.Request{Query: `KEY(cnn) AND "image recognition"`, PerPage: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 1 {
+		t.Fatalf("blobs: have %d, want 1", resp.Len())
+	}
+	p, err = c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]string{
+		KeyAuthors:   "Guo, Kaiyuan and Sui, Lingzhi",
+		KeyPageRange: "35-47",
+		KeyIssue:     "1",
+		KeyDOI:       "10.1109/TCAD.2017.2705069",
+	}
+	for k, want := range values {
+		if have := p.Values[k]; have != want {
+			t.Errorf("%s: have %q, want %q", k, have, want)
+		}
+	}
+	if !strings.HasSuffix(p.Abstract.Text, "method for image recognition.") {
+		t.Fatalf("continuation lines not joined: %q", p.Abstract.Text)
+	}
+}
+
+func TestParseRISErrors(t *testing.T) {
+	for _, v := range []string{
+		"TI  - no type\nER  - \n",
+		"TY  - JOUR\nTI  - unterminated\n",
+		"TY  - JOUR\nTY  - JOUR\nER  - \n",
+	} {
+		if _, err := parseRIS(strings.NewReader(v)); err == nil {
+			t.Errorf("%q: expected an error", v)
+		}
+	}
+}
+
+func TestConformance(t *testing.T) {
+	for path, max := range map[string]int{"testdata/seeds.bib": 3, "testdata/seeds.ris": 2} {
+		t.Run(path, func(t *testing.T) {
+			littest.RunConformance(t, NewClient(path), littest.Fixture{Query: "*", PerPage: 2, Max: max})
+		})
+	}
+}
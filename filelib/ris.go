@@ -0,0 +1,81 @@
+package filelib
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// risRecord maps each tag of a RIS record to its values, in order of
+// appearance, as tags like AU and KW are repeated.
+type risRecord map[string][]string
+
+func (r risRecord) first(tags ...string) string {
+	for _, t := range tags {
+		if v := r[t]; len(v) > 0 && v[0] != "" {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+// risLine matches "TY  - JOUR". Some exporters omit the trailing space
+// of tags with no value, like "ER  -".
+var risLine = regexp.MustCompile(`^([A-Z][A-Z0-9])  -( (.*))?$`)
+
+// parseRIS reads the records of a RIS file. Lines not starting with a tag
+// continue the value of the previous one.
+func parseRIS(r io.Reader) ([]risRecord, error) {
+	records := []risRecord{}
+	var (
+		current risRecord
+		lastTag string
+		line    int
+	)
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+	for s.Scan() {
+		line++
+		text := strings.TrimRight(strings.TrimPrefix(s.Text(), "\ufeff"), " \r")
+		m := risLine.FindStringSubmatch(text)
+		if m == nil {
+			if current != nil && lastTag != "" && strings.TrimSpace(text) != "" {
+				values := current[lastTag]
+				values[len(values)-1] += " " + strings.TrimSpace(text)
+			}
+			continue
+		}
+
+		tag, value := m[1], strings.TrimSpace(m[3])
+		switch tag {
+		case "TY":
+			if current != nil {
+				return nil, fmt.Errorf("line %d: TY found before the ER closing the previous record", line)
+			}
+			current = risRecord{}
+		case "ER":
+			if current == nil {
+				return nil, fmt.Errorf("line %d: ER found outside of a record", line)
+			}
+			records = append(records, current)
+			current = nil
+			lastTag = ""
+			continue
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: %s found outside of a record", line, tag)
+		}
+		current[tag] = append(current[tag], value)
+		lastTag = tag
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		return nil, fmt.Errorf("line %d: last record is not terminated by ER", line)
+	}
+	return records, nil
+}
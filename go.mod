@@ -3,11 +3,11 @@ module github.com/jecoz/lit
 go 1.16
 
 require (
-	github.com/charmbracelet/bubbles v0.9.0 // indirect
-	github.com/charmbracelet/bubbletea v0.19.1 // indirect
-	github.com/charmbracelet/lipgloss v0.4.0 // indirect
-	github.com/jecoz/edb v0.0.0-20211204090620-dd9cdfedb4d1 // indirect
+	github.com/charmbracelet/bubbles v0.9.0
+	github.com/charmbracelet/bubbletea v0.19.1
+	github.com/charmbracelet/lipgloss v0.4.0
+	github.com/jecoz/edb v0.0.0-20211204090620-dd9cdfedb4d1
 	golang.org/x/crypto v0.0.0-20201012173705-84dcc777aaee // indirect
-	golang.org/x/net v0.0.0-20211123203042-d83791d6bcd9 // indirect
-	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c // indirect
+	golang.org/x/net v0.0.0-20211123203042-d83791d6bcd9
+	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
 )
@@ -0,0 +1,318 @@
+// Package httpcache caches the HTTP traffic of libraries on disk. Entries
+// are addressed by a hash of the normalized request, credentials excluded,
+// and stored as JSON files readable enough to be used as test fixtures.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Mode tells how a Transport uses its cache.
+type Mode int
+
+const (
+	// ModeCache serves fresh entries out of the cache, revalidates stale
+	// ones and stores successful responses.
+	ModeCache Mode = iota
+	// ModeRecord always asks the server, storing every response.
+	ModeRecord
+	// ModeReplay never asks the server: requests not in the cache fail
+	// with ErrNotCached.
+	ModeReplay
+)
+
+var modeNames = map[Mode]string{
+	ModeCache:  "cache",
+	ModeRecord: "record",
+	ModeReplay: "replay",
+}
+
+func (m Mode) String() string {
+	return modeNames[m]
+}
+
+// ParseMode returns the mode called s, one of "cache", "record" and
+// "replay".
+func ParseMode(s string) (Mode, error) {
+	for m, name := range modeNames {
+		if strings.EqualFold(s, name) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown cache mode %q, available ones are cache, record and replay", s)
+}
+
+// ErrNotCached is returned in replay mode by requests missing from the
+// cache.
+var ErrNotCached = errors.New("not cached")
+
+// sensitiveParams are query parameters carrying credentials or contacts,
+// left out of keys and entries.
+var sensitiveParams = map[string]bool{
+	"apikey":  true,
+	"api_key": true,
+	"mailto":  true,
+	"email":   true,
+}
+
+// droppedHeaders are response headers not worth storing: cookies belong
+// to a session, quotas are stale as soon as they are stored.
+var droppedHeaders = []string{
+	"Set-Cookie",
+	"X-Ratelimit-Limit",
+	"X-Ratelimit-Remaining",
+	"X-Ratelimit-Reset",
+}
+
+// Key returns the normalized form of r identifying its entry: method, URL
+// with sorted query parameters and no credentials, and Accept header.
+func Key(r *http.Request) string {
+	u := *r.URL
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.User = nil
+	q := u.Query()
+	for k := range q {
+		if sensitiveParams[strings.ToLower(k)] {
+			q.Del(k)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return r.Method + " " + u.String() + " " + r.Header.Get("Accept")
+}
+
+// Entry is a response stored in the cache.
+type Entry struct {
+	Key    string      `json:"key"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+	// Base64 tells that Body is base64 encoded, as it is not valid UTF-8.
+	Base64 bool `json:"base64,omitempty"`
+	// Time is when the entry was stored or last revalidated.
+	Time time.Time `json:"time"`
+}
+
+func newEntry(key string, resp *http.Response, body []byte) *Entry {
+	e := &Entry{
+		Key:    key,
+		Status: resp.StatusCode,
+		Header: resp.Header.Clone(),
+		Time:   time.Now(),
+	}
+	for _, v := range droppedHeaders {
+		e.Header.Del(v)
+	}
+	if utf8.Valid(body) {
+		e.Body = string(body)
+	} else {
+		e.Body = base64.StdEncoding.EncodeToString(body)
+		e.Base64 = true
+	}
+	return e
+}
+
+func (e *Entry) body() []byte {
+	if e.Base64 {
+		b, _ := base64.StdEncoding.DecodeString(e.Body)
+		return b
+	}
+	return []byte(e.Body)
+}
+
+// response returns e as the response to r.
+func (e *Entry) response(r *http.Request) *http.Response {
+	body := e.body()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}
+}
+
+// Transport is an http.RoundTripper caching the responses of Base in Dir.
+// Only GET and HEAD requests are cached.
+type Transport struct {
+	Dir  string
+	Mode Mode
+	// MaxAge is the age past which entries are revalidated in ModeCache,
+	// through their ETag or Last-Modified headers when they have one,
+	// requesting them again otherwise.
+	MaxAge time.Duration
+	// Base makes the actual requests, http.DefaultTransport when nil.
+	Base http.RoundTripper
+}
+
+// New returns a Transport caching the responses of base in dir.
+func New(dir string, mode Mode, maxAge time.Duration, base http.RoundTripper) *Transport {
+	return &Transport{Dir: dir, Mode: mode, MaxAge: maxAge, Base: base}
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+// Path returns the file holding the entry of key.
+func (t *Transport) Path(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return filepath.Join(t.Dir, hex.EncodeToString(h[:16])+".json")
+}
+
+func (t *Transport) load(key string) (*Entry, error) {
+	data, err := os.ReadFile(t.Path(key))
+	if err != nil {
+		return nil, err
+	}
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("cache entry %s: %w", t.Path(key), err)
+	}
+	return &e, nil
+}
+
+func (t *Transport) save(e *Entry) error {
+	// Unescaped, URLs in keys and bodies read as they are.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(e); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(t.Dir, ".entry")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(buf.Bytes())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), t.Path(e.Key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// store reads resp, saving it as the entry of key, and returns an
+// equivalent response.
+func (t *Transport) store(key string, resp *http.Response) (*http.Response, error) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	e := newEntry(key, resp, body)
+	if err := t.save(e); err != nil {
+		return nil, fmt.Errorf("store response: %w", err)
+	}
+	// The original headers, quota included, are still of use to the
+	// caller.
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		if t.Mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrNotCached, r.Method, r.URL.Path)
+		}
+		return t.base().RoundTrip(r)
+	}
+
+	key := Key(r)
+	switch t.Mode {
+	case ModeReplay:
+		e, err := t.load(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrNotCached, key)
+		}
+		return e.response(r), nil
+	case ModeRecord:
+		resp, err := t.base().RoundTrip(r)
+		if err != nil {
+			return nil, err
+		}
+		return t.store(key, resp)
+	}
+
+	e, err := t.load(key)
+	if err != nil || e.Status != http.StatusOK {
+		return t.fetch(key, r)
+	}
+	if time.Since(e.Time) <= t.MaxAge {
+		return e.response(r), nil
+	}
+	etag, modified := e.Header.Get("ETag"), e.Header.Get("Last-Modified")
+	if etag == "" && modified == "" {
+		return t.fetch(key, r)
+	}
+
+	cr := r.Clone(r.Context())
+	if etag != "" {
+		cr.Header.Set("If-None-Match", etag)
+	}
+	if modified != "" {
+		cr.Header.Set("If-Modified-Since", modified)
+	}
+	resp, err := t.base().RoundTrip(cr)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusNotModified {
+		if resp.StatusCode != http.StatusOK {
+			return resp, nil
+		}
+		return t.store(key, resp)
+	}
+	resp.Body.Close()
+	e.Time = time.Now()
+	if err := t.save(e); err != nil {
+		return nil, fmt.Errorf("store response: %w", err)
+	}
+	cached := e.response(r)
+	// The quota is reported by revalidations as well.
+	for k, v := range resp.Header {
+		if strings.HasPrefix(k, "X-Ratelimit-") {
+			cached.Header[k] = v
+		}
+	}
+	return cached, nil
+}
+
+// fetch runs r, storing the response when successful.
+func (t *Transport) fetch(key string, r *http.Request) (*http.Response, error) {
+	resp, err := t.base().RoundTrip(r)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	return t.store(key, resp)
+}
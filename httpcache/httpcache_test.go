@@ -0,0 +1,150 @@
+package httpcache
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type server struct {
+	requests    int
+	conditional int
+	etag        string
+	modified    string
+	body        string
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests++
+	w.Header().Set("X-RateLimit-Remaining", fmt.Sprintf("%d", 100-s.requests))
+	if s.etag != "" {
+		w.Header().Set("ETag", s.etag)
+	}
+	if s.modified != "" {
+		w.Header().Set("Last-Modified", s.modified)
+	}
+	inm, ims := r.Header.Get("If-None-Match"), r.Header.Get("If-Modified-Since")
+	if inm != "" || ims != "" {
+		s.conditional++
+	}
+	if (inm != "" && inm == s.etag) || (inm == "" && ims != "" && ims == s.modified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	fmt.Fprint(w, s.body)
+}
+
+func get(t *testing.T, c *http.Client, url string) (string, *http.Response) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body), resp
+}
+
+func TestKey(t *testing.T) {
+	a, _ := http.NewRequest(http.MethodGet, "https://API.example.com/search?query=fpga&apiKey=secret&count=25", nil)
+	b, _ := http.NewRequest(http.MethodGet, "https://api.example.com/search?count=25&query=fpga&mailto=me@example.com", nil)
+	if Key(a) != Key(b) {
+		t.Errorf("keys differ: %q, %q", Key(a), Key(b))
+	}
+	if strings.Contains(Key(a), "secret") {
+		t.Errorf("key %q holds credentials", Key(a))
+	}
+	b.Header.Set("Accept", "application/xml")
+	if Key(a) == Key(b) {
+		t.Errorf("keys of requests accepting different formats match")
+	}
+}
+
+func TestRevalidate(t *testing.T) {
+	for _, s := range []*server{
+		{etag: `"v1"`, body: "etag"},
+		{modified: "Sat, 01 May 2021 10:00:00 GMT", body: "last modified"},
+	} {
+		srv := httptest.NewServer(s)
+		defer srv.Close()
+		tr := New(t.TempDir(), ModeCache, time.Hour, nil)
+		c := &http.Client{Transport: tr}
+
+		for i := 0; i < 2; i++ {
+			if body, _ := get(t, c, srv.URL); body != s.body {
+				t.Fatalf("have body %q, want %q", body, s.body)
+			}
+		}
+		if s.requests != 1 {
+			t.Fatalf("fresh entry: have %d requests, want 1", s.requests)
+		}
+
+		tr.MaxAge = 0
+		body, resp := get(t, c, srv.URL)
+		if body != s.body || s.conditional != 1 {
+			t.Fatalf("stale entry: have body %q, %d conditional requests", body, s.conditional)
+		}
+		// The quota comes from the revalidation.
+		if have := resp.Header.Get("X-RateLimit-Remaining"); have != "98" {
+			t.Errorf("have remaining quota %q, want 98", have)
+		}
+
+		// A new version is stored.
+		s.etag, s.modified, s.body = `"v2"`, "", "changed"
+		if body, _ := get(t, c, srv.URL); body != "changed" {
+			t.Fatalf("have body %q after a change", body)
+		}
+		tr.MaxAge = time.Hour
+		if body, _ := get(t, c, srv.URL); body != "changed" || s.requests != 3 {
+			t.Fatalf("have body %q, %d requests", body, s.requests)
+		}
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	s := &server{body: "\xff\xfe binary"}
+	srv := httptest.NewServer(s)
+	dir := t.TempDir()
+
+	rec := &http.Client{Transport: New(dir, ModeRecord, 0, nil)}
+	for i := 0; i < 2; i++ {
+		get(t, rec, srv.URL+"/page?n=1")
+	}
+	if s.requests != 2 {
+		t.Fatalf("record: have %d requests, want 2", s.requests)
+	}
+	srv.Close()
+
+	tr := New(dir, ModeReplay, 0, nil)
+	replay := &http.Client{Transport: tr}
+	body, resp := get(t, replay, srv.URL+"/page?n=1")
+	if body != s.body || resp.StatusCode != http.StatusOK {
+		t.Fatalf("replay: have %d %q", resp.StatusCode, body)
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "" {
+		t.Errorf("quota headers were stored")
+	}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/page?n=1", nil)
+	req.Header.Set("Accept", "application/json")
+	data, err := os.ReadFile(tr.Path(Key(req)))
+	if err != nil || !strings.Contains(string(data), `"base64": true`) {
+		t.Errorf("unexpected entry %s, %v", data, err)
+	}
+
+	if _, err := replay.Get(srv.URL + "/page?n=2"); !errors.Is(err, ErrNotCached) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
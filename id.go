@@ -0,0 +1,41 @@
+package lit
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+)
+
+// Identifier is implemented by libraries whose publications carry an
+// identifier assigned by the library itself, such as Scopus' EID.
+type Identifier interface {
+	// NativeID returns the identifier of p within the library, or an
+	// empty string when p does not have one.
+	NativeID(Publication) string
+}
+
+// PublicationID returns a stable identifier of p, derived from its
+// contents: the same publication downloaded twice from the same library
+// gets the same identifier, regardless of the order of the downloads. It
+// is made of the library name followed by the native identifier of p, if
+// lib provides one, its DOI otherwise. Publications with neither are
+// identified by a hash of their title, creator and cover date.
+func PublicationID(lib Library, p Publication) string {
+	prefix := lib.GetName() + ":"
+	var i Identifier
+	if As(lib, &i) {
+		if id := i.NativeID(p); id != "" {
+			return prefix + id
+		}
+	}
+	if doi := strings.ToLower(strings.TrimSpace(p.DOI())); doi != "" {
+		return prefix + "doi:" + doi
+	}
+	h := sha1.New()
+	h.Write([]byte(strings.ToLower(p.Title)))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.ToLower(p.Creator)))
+	h.Write([]byte{0})
+	h.Write([]byte(p.CoverDate.Format("2006-01-02")))
+	return prefix + "sha1:" + hex.EncodeToString(h.Sum(nil))[:16]
+}
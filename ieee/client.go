@@ -0,0 +1,474 @@
+package ieee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+)
+
+const endpoint = "https://ieeexploreapi.ieee.org/api/v1/search/articles"
+
+const (
+	KeyArticleNumber      = "article_number"
+	KeyDOI                = lit.KeyDOI
+	KeyIssn               = lit.KeyIssn
+	KeyIsbn               = "isbn"
+	KeyAuthors            = lit.KeyAuthors
+	KeyPublisher          = lit.KeyPublisher
+	KeyPublicationName    = lit.KeyPublicationName
+	KeyVolume             = lit.KeyVolume
+	KeyIssue              = lit.KeyIssue
+	KeyPageRange          = lit.KeyPageRange
+	KeyContentType        = "content_type"
+	KeyConferenceLocation = "conference_location"
+	KeyLinkAbstract       = lit.KeyLinkAbstract
+	KeyLinkPDF            = "link_pdf"
+	KeyCitedByCount       = "cited_by_count"
+	KeyAffiliation        = "affiliation"
+	KeySourceKeywords     = "source_keywords"
+	KeyIndexTerms         = "index_terms"
+)
+
+// Content types accepted by the content_type search parameter.
+const (
+	ContentTypeBooks       = "Books"
+	ContentTypeConferences = "Conferences"
+	ContentTypeCourses     = "Courses"
+	ContentTypeEarlyAccess = "Early Access"
+	ContentTypeJournals    = "Journals"
+	ContentTypeMagazines   = "Magazines"
+	ContentTypeStandards   = "Standards"
+)
+
+type Client struct {
+	apiKey      string
+	contentType string
+	endpoint    string
+	httpClient  *http.Client
+}
+
+// WithContentType returns a copy of c restricting searches to a single
+// content type, e.g. ContentTypeConferences.
+func (c Client) WithContentType(t string) Client {
+	c.contentType = t
+	return c
+}
+
+func (c Client) DefaultPerPage() int {
+	return 200
+}
+
+func (c Client) newRequest(ctx context.Context, q url.Values) *http.Request {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		panic(err)
+	}
+	q.Set("apikey", c.apiKey)
+	q.Set("format", "json")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	return req
+}
+
+func (c Client) newSearchRequest(ctx context.Context, src lit.Request) *http.Request {
+	q := url.Values{}
+	q.Set("querytext", src.Query)
+	// Records are numbered starting from 1.
+	q.Set("start_record", fmt.Sprintf("%d", src.PerPage*src.Page+1))
+	q.Set("max_records", fmt.Sprintf("%d", src.PerPage))
+	if c.contentType != "" {
+		q.Set("content_type", c.contentType)
+	}
+	return c.newRequest(ctx, q)
+}
+
+// The gateway in front of the API answers with plain text or HTML
+// messages, e.g. "<h1>Developer Inactive</h1>".
+func extractError(r *http.Response) error {
+	msg, err := io.ReadAll(io.LimitReader(r.Body, 512))
+	if err != nil {
+		return lit.NewStatusError(r, "")
+	}
+	return lit.NewStatusError(r, string(bytes.TrimSpace(msg)))
+}
+
+type searchResults struct {
+	Total    int               `json:"total_records"`
+	Articles []json.RawMessage `json:"articles"`
+}
+
+func (c Client) search(req *http.Request) (searchResults, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return searchResults{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return searchResults{}, extractError(resp)
+	}
+
+	var p searchResults
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return searchResults{}, err
+	}
+	return p, nil
+}
+
+func (c Client) GetLiterature(ctx context.Context, req lit.Request) (lit.Response, error) {
+	p, err := c.search(c.newSearchRequest(ctx, req))
+	if err != nil {
+		return lit.Response{}, err
+	}
+	blobs := make([]lit.Blob, len(p.Articles))
+	for i, v := range p.Articles {
+		blobs[i] = lit.Blob(v)
+	}
+	return lit.Response{
+		Req:   req,
+		Blobs: blobs,
+	}, nil
+}
+
+func (c Client) GetMaxLiterature(ctx context.Context, req lit.Request) (int, error) {
+	req.Page = 0
+	req.PerPage = 1
+	p, err := c.search(c.newSearchRequest(ctx, req))
+	if err != nil {
+		return 0, err
+	}
+	return p.Total, nil
+}
+
+func (c Client) GetRateLimit() time.Duration {
+	// https://developer.ieee.org/API_Terms_of_Use2
+	return time.Millisecond * 1000 / time.Duration(10)
+}
+
+func (c Client) ConcurrencyLimit() int {
+	return 5
+}
+
+func (c Client) PrettyPrint(b lit.Blob, dst *bytes.Buffer) error {
+	return json.Indent(dst, []byte(b), "", "\t")
+}
+
+// number decodes integers the API encodes either as JSON numbers or
+// strings, depending on the record.
+type number int
+
+func (n *number) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("unexpected number %s: %w", b, err)
+	}
+	*n = number(v)
+	return nil
+}
+
+type author struct {
+	FullName    string `json:"full_name"`
+	Affiliation string `json:"affiliation"`
+	Order       number `json:"author_order"`
+}
+
+type terms struct {
+	Terms []string `json:"terms"`
+}
+
+type article struct {
+	ArticleNumber      string `json:"article_number"`
+	DOI                string `json:"doi"`
+	Title              string `json:"title"`
+	Abstract           string `json:"abstract"`
+	Publisher          string `json:"publisher"`
+	PublicationTitle   string `json:"publication_title"`
+	PublicationYear    number `json:"publication_year"`
+	ContentType        string `json:"content_type"`
+	Isbn               string `json:"isbn"`
+	Issn               string `json:"issn"`
+	Volume             string `json:"volume"`
+	Issue              string `json:"issue"`
+	StartPage          string `json:"start_page"`
+	EndPage            string `json:"end_page"`
+	ConferenceLocation string `json:"conference_location"`
+	AbstractURL        string `json:"abstract_url"`
+	HTMLURL            string `json:"html_url"`
+	PdfURL             string `json:"pdf_url"`
+	CitingPaperCount   number `json:"citing_paper_count"`
+	Authors            struct {
+		Authors []author `json:"authors"`
+	} `json:"authors"`
+	IndexTerms struct {
+		AuthorTerms terms `json:"author_terms"`
+		IEEETerms   terms `json:"ieee_terms"`
+	} `json:"index_terms"`
+}
+
+func (a article) CoverDate() (time.Time, error) {
+	if a.PublicationYear == 0 {
+		return time.Time{}, fmt.Errorf("parse publication year: no year available")
+	}
+	return time.Date(int(a.PublicationYear), time.January, 1, 0, 0, 0, 0, time.UTC), nil
+}
+
+func (a article) Creator() string {
+	for _, v := range a.Authors.Authors {
+		if v.Order == 1 {
+			return v.FullName
+		}
+	}
+	if len(a.Authors.Authors) > 0 {
+		return a.Authors.Authors[0].FullName
+	}
+	return ""
+}
+
+// AuthorList returns the authors of the article in their order.
+func (a article) AuthorList() []lit.Author {
+	if len(a.Authors.Authors) == 0 {
+		return nil
+	}
+	ordered := make([]author, len(a.Authors.Authors))
+	copy(ordered, a.Authors.Authors)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Order < ordered[j].Order })
+	authors := make([]lit.Author, len(ordered))
+	for i, v := range ordered {
+		authors[i] = lit.Author{Name: v.FullName, Affiliation: v.Affiliation}
+	}
+	return authors
+}
+
+// DocumentType maps the content type of the article onto the types of
+// lit.
+func (a article) DocumentType() lit.DocumentType {
+	switch a.ContentType {
+	case "Journals", "Magazines", "Early Access Articles":
+		return lit.TypeArticle
+	case "Conferences":
+		return lit.TypeConferencePaper
+	case "Books":
+		return lit.TypeBookChapter
+	case "":
+		return ""
+	default:
+		return lit.TypeOther
+	}
+}
+
+func (a article) PageRange() string {
+	switch {
+	case a.StartPage == "":
+		return ""
+	case a.EndPage == "" || a.EndPage == a.StartPage:
+		return a.StartPage
+	default:
+		return a.StartPage + "-" + a.EndPage
+	}
+}
+
+func (a article) Values() map[string]string {
+	authors := make([]string, len(a.Authors.Authors))
+	seen := make(map[string]bool)
+	affiliations := []string{}
+	for i, v := range a.Authors.Authors {
+		authors[i] = v.FullName
+		if v.Affiliation == "" || seen[v.Affiliation] {
+			continue
+		}
+		seen[v.Affiliation] = true
+		affiliations = append(affiliations, v.Affiliation)
+	}
+
+	link := a.HTMLURL
+	if link == "" {
+		link = a.AbstractURL
+	}
+
+	return map[string]string{
+		KeyArticleNumber:      a.ArticleNumber,
+		KeyDOI:                a.DOI,
+		KeyIssn:               a.Issn,
+		KeyIsbn:               a.Isbn,
+		KeyAuthors:            strings.Join(authors, " and "),
+		KeyPublisher:          a.Publisher,
+		KeyPublicationName:    a.PublicationTitle,
+		KeyVolume:             a.Volume,
+		KeyIssue:              a.Issue,
+		KeyPageRange:          a.PageRange(),
+		KeyContentType:        a.ContentType,
+		KeyConferenceLocation: a.ConferenceLocation,
+		KeyLinkAbstract:       link,
+		KeyLinkPDF:            a.PdfURL,
+		KeyCitedByCount:       fmt.Sprintf("%d", a.CitingPaperCount),
+		KeyAffiliation:        strings.Join(affiliations, "; "),
+		KeySourceKeywords:     strings.Join(a.IndexTerms.AuthorTerms.Terms, ", "),
+		KeyIndexTerms:         strings.Join(a.IndexTerms.IEEETerms.Terms, ", "),
+	}
+}
+
+func parseArticle(b []byte) (lit.Publication, error) {
+	var a article
+	if err := json.Unmarshal(b, &a); err != nil {
+		return lit.Publication{}, err
+	}
+	coverDate, err := a.CoverDate()
+	if err != nil {
+		return lit.Publication{}, fmt.Errorf("article %s: %w", a.ArticleNumber, err)
+	}
+
+	citedBy := int(a.CitingPaperCount)
+	p := lit.Publication{
+		Title:     a.Title,
+		CoverDate: coverDate,
+		Creator:   a.Creator(),
+		Authors:   a.AuthorList(),
+		IDs: lit.Identifiers{
+			DOI:  a.DOI,
+			ISBN: a.Isbn,
+		},
+		Venue:          a.PublicationTitle,
+		Volume:         a.Volume,
+		Issue:          a.Issue,
+		Pages:          a.PageRange(),
+		Type:           a.DocumentType(),
+		CitedBy:        &citedBy,
+		SourceKeywords: a.IndexTerms.AuthorTerms.Terms,
+		Values:         a.Values(),
+	}
+	if a.Abstract != "" {
+		p.Abstract = &lit.Abstract{Text: a.Abstract}
+	}
+	return p, nil
+}
+
+func (c Client) ParsePublication(b lit.Blob) (lit.Publication, error) {
+	return parseArticle([]byte(b))
+}
+
+func (c Client) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	if p.Abstract != nil {
+		return *p.Abstract, nil
+	}
+
+	n := p.Values[KeyArticleNumber]
+	if n == "" {
+		return lit.Abstract{}, fmt.Errorf("publication %q has no IEEE article number", p.Title)
+	}
+	res, err := c.search(c.newRequest(ctx, url.Values{"article_number": {n}}))
+	if err != nil {
+		return lit.Abstract{}, err
+	}
+	if len(res.Articles) == 0 {
+		return lit.Abstract{}, fmt.Errorf("article %s not found", n)
+	}
+	pub, err := parseArticle(res.Articles[0])
+	if err != nil {
+		return lit.Abstract{}, err
+	}
+	if pub.Abstract == nil {
+		return lit.Abstract{}, fmt.Errorf("article %s has no abstract", n)
+	}
+	return *pub.Abstract, nil
+}
+
+// HTTPClient returns the client used to talk to the API, see
+// lit.HTTPLibrary.
+func (c Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+func (c Client) GetName() string {
+	return "IEEE Xplore"
+}
+
+// NativeID returns the IEEE Xplore article number of p.
+func (c Client) NativeID(p lit.Publication) string {
+	return p.Values[KeyArticleNumber]
+}
+
+func (c Client) ToBibTeX(p lit.Publication) bibtex.Reference {
+	entry := p.BibTeXEntry()
+	venue := p.Values[KeyPublicationName]
+
+	switch p.Values[KeyContentType] {
+	case ContentTypeJournals, ContentTypeMagazines, ContentTypeEarlyAccess, "Early Access Articles":
+		return bibtex.Article{
+			Entry:     entry,
+			Journal:   venue,
+			Volume:    p.Value(KeyVolume),
+			Number:    p.Value(KeyIssue),
+			PageRange: p.Value(KeyPageRange),
+		}
+	case ContentTypeConferences:
+		return bibtex.InProceedings{
+			Entry:     entry,
+			BookTitle: venue,
+			PageRange: p.Value(KeyPageRange),
+			Publisher: p.Value(KeyPublisher),
+			Address:   p.Value(KeyConferenceLocation),
+		}
+	case ContentTypeBooks:
+		// Xplore indexes book chapters rather than whole books.
+		return bibtex.InCollection{
+			Entry:     entry,
+			BookTitle: venue,
+			Publisher: p.Values[KeyPublisher],
+			PageRange: p.Value(KeyPageRange),
+		}
+	case ContentTypeStandards:
+		kind := "Standard"
+		return bibtex.TechReport{
+			Entry:       entry,
+			Institution: p.Values[KeyPublisher],
+			Type:        &kind,
+		}
+	default:
+		note := fmt.Sprintf("%q", p.Values)
+		return bibtex.Misc{
+			Entry: entry,
+			Note:  &note,
+		}
+	}
+}
+
+func (c Client) ReferenceLink(p lit.Publication) string {
+	if l := p.Values[KeyLinkAbstract]; l != "" {
+		return l
+	}
+	return "https://ieeexplore.ieee.org/document/" + p.Values[KeyArticleNumber]
+}
+
+func NewClient(apiKey string) Client {
+	tr := &http.Transport{
+		MaxIdleConns:       10,
+		IdleConnTimeout:    15 * time.Second,
+		DisableCompression: false,
+	}
+
+	return Client{
+		apiKey:     apiKey,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Transport: tr},
+	}
+}
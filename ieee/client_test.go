@@ -0,0 +1,227 @@
+package ieee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/littest"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c := NewClient("secret")
+	c.endpoint = srv.URL
+	return c
+}
+
+func TestGetLiterature(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		want := map[string]string{
+			"apikey":       "secret",
+			"querytext":    "fpga AND cnn",
+			"start_record": "51",
+			"max_records":  "25",
+			"content_type": "Conferences",
+		}
+		for k, v := range want {
+			if have := q.Get(k); have != v {
+				t.Errorf("%s: have %q, want %q", k, have, v)
+			}
+		}
+		fmt.Fprint(w, searchPage)
+	})
+
+	c = c.WithContentType(ContentTypeConferences)
+	resp, err := c.GetLiterature(context.Background(), lit.Request{
+		Query:   "fpga AND cnn",
+		Page:    2,
+		PerPage: 25,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 3 {
+		t.Fatalf("blobs: have %d, want 3", resp.Len())
+	}
+
+	tt := []struct {
+		entryType bibtex.EntryType
+		field     string
+		value     string
+	}{
+		{bibtex.EntryTypeInProceedings, "address", "Munich, Germany"},
+		{bibtex.EntryTypeArticle, "number", "4"},
+		{bibtex.EntryTypeTechReport, "institution", "IEEE"},
+	}
+	for i, v := range tt {
+		p, err := c.ParsePublication(resp.Blobs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		ref := c.ToBibTeX(p)
+		if ref.EntryType() != v.entryType {
+			t.Errorf("blob %d: entry type: have %q, want %q", i, ref.EntryType(), v.entryType)
+		}
+		if have := ref.Fields()[v.field]; have != v.value {
+			t.Errorf("blob %d: %s: have %q, want %q", i, v.field, have, v.value)
+		}
+	}
+}
+
+func TestParsePublication(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, searchPage)
+	})
+	resp, err := c.GetLiterature(context.Background(), lit.Request{Query: "fpga", PerPage: 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.CoverDate.Year() != 2019 {
+		t.Fatalf("cover date: have %v", p.CoverDate)
+	}
+	if want := "Jane Doe"; p.Creator != want {
+		t.Fatalf("creator: have %q, want %q", p.Creator, want)
+	}
+	values := map[string]string{
+		KeyArticleNumber:   "8891234",
+		KeyAuthors:         "Jane Doe and John Roe",
+		KeyPageRange:       "10-17",
+		KeySourceKeywords:  "FPGA, CNN",
+		KeyPublicationName: "2019 29th International Conference on Field Programmable Logic and Applications (FPL)",
+	}
+	for k, want := range values {
+		if have := p.Values[k]; have != want {
+			t.Errorf("%s: have %q, want %q", k, have, want)
+		}
+	}
+	if p.Abstract == nil || p.Abstract.Text != "We present an accelerator." {
+		t.Fatalf("abstract: have %+v", p.Abstract)
+	}
+	if len(p.Authors) != 2 || p.Authors[1].Name != "John Roe" || p.Type != lit.TypeConferencePaper || p.Pages != "10-17" || len(p.SourceKeywords) != 2 {
+		t.Fatalf("unexpected metadata %+v, %s, %q, %v", p.Authors, p.Type, p.Pages, p.SourceKeywords)
+	}
+}
+
+func TestGetMaxLiterature(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, searchPage)
+	})
+	max, err := c.GetMaxLiterature(context.Background(), lit.Request{Query: "fpga"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if max != 812 {
+		t.Fatalf("max literature: have %d, want 812", max)
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "<h1>Developer Inactive</h1>")
+	})
+	_, err := c.GetMaxLiterature(context.Background(), lit.Request{Query: "fpga"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "403 Forbidden: <h1>Developer Inactive</h1>"; err.Error() != want {
+		t.Fatalf("error: have %q, want %q", err, want)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	var page struct {
+		Articles []json.RawMessage `json:"articles"`
+	}
+	if err := json.Unmarshal([]byte(searchPage), &page); err != nil {
+		t.Fatal(err)
+	}
+	// Standards, the last article, have no authors to cite.
+	articles := littest.Records(42, page.Articles[:2], func(i int, a map[string]interface{}) {
+		a["article_number"] = fmt.Sprintf("%d", 9100000+i)
+		a["doi"] = fmt.Sprintf("10.1109/FPL.2019.%d", i)
+		a["title"] = fmt.Sprintf("%s, part %d", a["title"], i)
+	})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		start, _ := strconv.Atoi(q.Get("start_record"))
+		max, _ := strconv.Atoi(q.Get("max_records"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total_records": len(articles),
+			"articles":      littest.Page(articles, start-1, max),
+		})
+	})
+	littest.RunConformance(t, c, littest.Fixture{Query: "fpga", PerPage: 10, Max: 42})
+}
+
+// Trimmed down response of the Metadata Search API, anonymized.
+const searchPage = `{
+  "total_records": 812,
+  "total_searched": 5602375,
+  "articles": [
+    {
+      "doi": "10.1109/FPL.2019.00012",
+      "title": "A Streaming CNN Accelerator",
+      "publisher": "IEEE",
+      "isbn": "978-1-7281-4884-7",
+      "issn": "1946-147X",
+      "rank": 1,
+      "authors": {"authors": [
+        {"affiliation": "TU Munich", "full_name": "Jane Doe", "author_order": 1},
+        {"affiliation": "TU Munich", "full_name": "John Roe", "author_order": 2}
+      ]},
+      "content_type": "Conferences",
+      "abstract": "We present an accelerator.",
+      "article_number": "8891234",
+      "publication_title": "2019 29th International Conference on Field Programmable Logic and Applications (FPL)",
+      "conference_location": "Munich, Germany",
+      "publication_year": 2019,
+      "start_page": "10",
+      "end_page": "17",
+      "citing_paper_count": 12,
+      "html_url": "https://ieeexplore.ieee.org/document/8891234/",
+      "index_terms": {
+        "ieee_terms": {"terms": ["Field programmable gate arrays"]},
+        "author_terms": {"terms": ["FPGA", "CNN"]}
+      }
+    },
+    {
+      "doi": "10.1109/TC.2020.1234567",
+      "title": "FPGA Overlays for CNNs",
+      "publisher": "IEEE",
+      "authors": {"authors": [{"full_name": "Ana Lee", "author_order": "1"}]},
+      "content_type": "Journals",
+      "article_number": "9000001",
+      "publication_title": "IEEE Transactions on Computers",
+      "publication_year": "2020",
+      "volume": "69",
+      "issue": "4",
+      "start_page": "501",
+      "end_page": "514",
+      "citing_paper_count": "3"
+    },
+    {
+      "title": "IEEE Standard for Floating-Point Arithmetic",
+      "publisher": "IEEE",
+      "authors": {"authors": []},
+      "content_type": "Standards",
+      "article_number": "8766229",
+      "publication_title": "IEEE Std 754-2019",
+      "publication_year": 2019
+    }
+  ]
+}`
@@ -0,0 +1,143 @@
+// Package libs lets commands select the lit.Library implementation to use
+// by name, reading credentials from the environment.
+package libs
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/arxiv"
+	"github.com/jecoz/lit/crossref"
+	"github.com/jecoz/lit/dblp"
+	"github.com/jecoz/lit/filelib"
+	"github.com/jecoz/lit/ieee"
+	"github.com/jecoz/lit/openalex"
+	"github.com/jecoz/lit/pubmed"
+	"github.com/jecoz/lit/scopus"
+	"github.com/jecoz/lit/semanticscholar"
+)
+
+const Default = "scopus"
+
+// FilePrefix selects a local .bib or .ris file as library, e.g.
+// "file:seeds.bib".
+const FilePrefix = "file:"
+
+var openers = map[string]func() lit.Library{
+	"scopus": func() lit.Library {
+		c := scopus.NewClient(os.Getenv("SCOPUS_API_KEY"))
+		if v := os.Getenv("SCOPUS_VIEW"); v != "" {
+			c = c.WithView(strings.ToUpper(v))
+		}
+		return c
+	},
+	"arxiv": func() lit.Library {
+		return arxiv.NewClient()
+	},
+	"crossref": func() lit.Library {
+		return crossref.NewClient(os.Getenv("CROSSREF_MAILTO"))
+	},
+	"ieee": func() lit.Library {
+		c := ieee.NewClient(os.Getenv("IEEE_API_KEY"))
+		if t := os.Getenv("IEEE_CONTENT_TYPE"); t != "" {
+			c = c.WithContentType(t)
+		}
+		return c
+	},
+	"openalex": func() lit.Library {
+		return openalex.NewClient(os.Getenv("OPENALEX_MAILTO"))
+	},
+	"semanticscholar": func() lit.Library {
+		return semanticscholar.NewClient(os.Getenv("S2_API_KEY"))
+	},
+	"pubmed": func() lit.Library {
+		return pubmed.NewClient(os.Getenv("NCBI_API_KEY"))
+	},
+}
+
+func init() {
+	// Registered here as the opener refers to Open, which would
+	// otherwise make openers depend on itself.
+	openers["dblp"] = func() lit.Library {
+		name := os.Getenv("DBLP_ABSTRACTS")
+		if name == "" {
+			name = "openalex"
+		}
+		c := dblp.NewClient()
+		fallback, err := Open(name)
+		var abstracts lit.AbstractProvider
+		if err != nil || name == "dblp" || !lit.As(fallback, &abstracts) {
+			// Abstracts will not be available, DBLP still works.
+			return c
+		}
+		// Abstracts are asked of the fallback directly, past the
+		// middlewares commands decorate DBLP with: it needs its own.
+		decorated, err := Decorate([]lit.Library{fallback})
+		if err != nil {
+			// Reported once commands decorate DBLP.
+			return c
+		}
+		return c.WithAbstracts(decorated[0])
+	}
+}
+
+// Names returns the sorted list of libraries known to Open.
+func Names() []string {
+	names := make([]string, 0, len(openers))
+	for k := range openers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Usage is meant to be used as the help string of command line flags
+// selecting a library.
+func Usage() string {
+	return fmt.Sprintf("Comma separated list of libraries to query, among: %s. Use %s<path> to search a local .bib or .ris file.", strings.Join(Names(), ", "), FilePrefix)
+}
+
+func Open(name string) (lit.Library, error) {
+	if strings.HasPrefix(name, FilePrefix) {
+		return filelib.NewClient(strings.TrimPrefix(name, FilePrefix)), nil
+	}
+	open, ok := openers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown library %q, available ones are: %s", name, strings.Join(Names(), ", "))
+	}
+	lib := open()
+	if err := installCache(lib); err != nil {
+		return nil, err
+	}
+	return lib, nil
+}
+
+// OpenList opens each library of the comma separated list names, to be
+// searched together.
+func OpenList(names string) ([]lit.Library, error) {
+	libs := []lit.Library{}
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		lib, err := Open(name)
+		if err != nil {
+			return nil, err
+		}
+		// Libraries are told apart by name in the edb.
+		if seen[lib.GetName()] {
+			return nil, fmt.Errorf("library %s selected more than once", lib.GetName())
+		}
+		seen[lib.GetName()] = true
+		libs = append(libs, lib)
+	}
+	if len(libs) == 0 {
+		return nil, fmt.Errorf("no library selected")
+	}
+	return libs, nil
+}
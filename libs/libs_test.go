@@ -0,0 +1,83 @@
+package libs
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jecoz/lit"
+)
+
+// abstractLibrary serves abstracts only, recording when they are asked.
+type abstractLibrary struct {
+	mu    sync.Mutex
+	calls []time.Time
+}
+
+func (l *abstractLibrary) GetName() string                           { return "abstracts" }
+func (l *abstractLibrary) GetRateLimit() time.Duration               { return time.Millisecond * 50 }
+func (l *abstractLibrary) DefaultPerPage() int                       { return 25 }
+func (l *abstractLibrary) PrettyPrint(lit.Blob, *bytes.Buffer) error { return nil }
+
+func (l *abstractLibrary) GetLiterature(context.Context, lit.Request) (lit.Response, error) {
+	return lit.Response{}, nil
+}
+
+func (l *abstractLibrary) GetMaxLiterature(context.Context, lit.Request) (int, error) {
+	return 0, nil
+}
+
+func (l *abstractLibrary) ParsePublication(lit.Blob) (lit.Publication, error) {
+	return lit.Publication{}, nil
+}
+
+func (l *abstractLibrary) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, time.Now())
+	return lit.Abstract{Text: "abstract of " + p.DOI()}, nil
+}
+
+func setenv(t *testing.T, key, value string) {
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestDBLPFallbackDecorated(t *testing.T) {
+	fallback := &abstractLibrary{}
+	openers["abstracts"] = func() lit.Library { return fallback }
+	t.Cleanup(func() { delete(openers, "abstracts") })
+	setenv(t, "DBLP_ABSTRACTS", "abstracts")
+	setenv(t, "LIT_CACHE", "")
+	setenv(t, "LIT_FAULTS", "")
+
+	lib, err := Open("dblp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var abstracts lit.AbstractProvider
+	if !lit.As(lib, &abstracts) {
+		t.Fatal("dblp has no abstracts")
+	}
+	p := lit.Publication{IDs: lit.Identifiers{DOI: "10.1145/3020078.3021740"}}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := abstracts.GetAbstract(context.Background(), p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first request goes right away, the others wait their turn.
+	if d, want := fallback.calls[2].Sub(start), 2*fallback.GetRateLimit(); d < want {
+		t.Errorf("3 abstracts asked in %v, want at least %v", d, want)
+	}
+}
@@ -0,0 +1,76 @@
+package libs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jecoz/edb"
+	"github.com/jecoz/lit"
+)
+
+// Quotas holds the latest quota of each library, by name, as stored in
+// the edb by set_quota events.
+type Quotas map[string]lit.Quota
+
+// Revive records the quota carried by e, if it is a set_quota event.
+func (qs Quotas) Revive(e edb.Event) error {
+	if e.Action != "set_quota" {
+		return nil
+	}
+	name, q, err := lit.UnmarshalQuota(e.Data)
+	if err != nil {
+		return fmt.Errorf("set_quota: %w", err)
+	}
+	qs[name] = q
+	return nil
+}
+
+// Save appends a set_quota event for each of clients whose quota changed
+// since the one stored last.
+func (qs Quotas) Save(db *edb.Db, clients []lit.Library) error {
+	for _, v := range clients {
+		var r lit.QuotaReporter
+		if !lit.As(v, &r) {
+			continue
+		}
+		q, ok := r.Quota()
+		if !ok {
+			continue
+		}
+		if last, ok := qs[v.GetName()]; ok && last.Equal(q) {
+			continue
+		}
+		if err := db.Append(&edb.Event{
+			Id:     fmt.Sprintf("%d", time.Now().UnixNano()),
+			Issuer: v.GetName(),
+			Scope:  "lit",
+			Action: "set_quota",
+			Data:   q.Marshal(v.GetName()),
+		}); err != nil {
+			return err
+		}
+		qs[v.GetName()] = q
+	}
+	return nil
+}
+
+// Notes describes the quota of each of clients, the one reported by the
+// library when available, the stored one otherwise.
+func (qs Quotas) Notes(clients []lit.Library) []string {
+	notes := []string{}
+	now := time.Now()
+	for _, v := range clients {
+		q, ok := qs[v.GetName()]
+		var r lit.QuotaReporter
+		if lit.As(v, &r) {
+			if live, liveOK := r.Quota(); liveOK {
+				q, ok = live, true
+			}
+		}
+		if !ok {
+			continue
+		}
+		notes = append(notes, fmt.Sprintf("%s quota: %v", v.GetName(), q.At(now)))
+	}
+	return notes
+}
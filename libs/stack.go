@@ -0,0 +1,107 @@
+package libs
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/httpcache"
+	"github.com/jecoz/lit/middleware"
+)
+
+// DefaultCacheMaxAge is the age past which cached responses are
+// revalidated, unless LIT_CACHE_MAX_AGE says otherwise.
+const DefaultCacheMaxAge = time.Hour * 24
+
+// cacheConfig reads the configuration of the HTTP cache from the
+// environment, returning an empty dir when disabled.
+func cacheConfig() (dir string, mode httpcache.Mode, maxAge time.Duration, err error) {
+	dir = os.Getenv("LIT_CACHE")
+	if dir == "" {
+		return "", 0, 0, nil
+	}
+	if v := os.Getenv("LIT_CACHE_MODE"); v != "" {
+		if mode, err = httpcache.ParseMode(v); err != nil {
+			return "", 0, 0, fmt.Errorf("LIT_CACHE_MODE: %w", err)
+		}
+	}
+	maxAge = DefaultCacheMaxAge
+	if v := os.Getenv("LIT_CACHE_MAX_AGE"); v != "" {
+		if maxAge, err = time.ParseDuration(v); err != nil {
+			return "", 0, 0, fmt.Errorf("LIT_CACHE_MAX_AGE: %w", err)
+		}
+	}
+	return dir, mode, maxAge, nil
+}
+
+// installCache makes lib cache its HTTP traffic, when LIT_CACHE is set.
+func installCache(lib lit.Library) error {
+	dir, mode, maxAge, err := cacheConfig()
+	if err != nil || dir == "" {
+		return err
+	}
+	var h lit.HTTPLibrary
+	if !lit.As(lib, &h) {
+		return nil
+	}
+	c := h.HTTPClient()
+	c.Transport = httpcache.New(dir, mode, maxAge, c.Transport)
+	return nil
+}
+
+// Stack returns the middlewares commands decorate libraries with: each
+// library is rate limited, retried and measured. The environment enables
+// the other ones:
+//
+//	LIT_LOG            file logging every request
+//	LIT_FAULTS         rate of requests to fail on purpose, e.g. 0.1
+//
+// HTTP responses are cached by the libraries themselves, see Open:
+//
+//	LIT_CACHE          directory caching responses
+//	LIT_CACHE_MODE     cache (default), record or replay, see httpcache
+//	LIT_CACHE_MAX_AGE  age past which cached responses are revalidated
+func Stack() ([]middleware.Middleware, error) {
+	_, mode, _, err := cacheConfig()
+	if err != nil {
+		return nil, err
+	}
+	mws := []middleware.Middleware{middleware.Retry(lit.DefaultRetryPolicy)}
+	if mode != httpcache.ModeReplay {
+		// Replayed responses do not reach the server.
+		mws = append(mws, middleware.RateLimit(0))
+	}
+	if path := os.Getenv("LIT_LOG"); path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("LIT_LOG: %w", err)
+		}
+		mws = append(mws, middleware.Logging(log.New(f, "", log.LstdFlags)))
+	}
+	mws = append(mws, middleware.Measure())
+	if v := os.Getenv("LIT_FAULTS"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("LIT_FAULTS: %q is not a rate between 0 and 1", v)
+		}
+		mws = append(mws, middleware.Faults(rate, time.Now().UnixNano()))
+	}
+	return mws, nil
+}
+
+// Decorate decorates each of clients with the middlewares returned by
+// Stack.
+func Decorate(clients []lit.Library) ([]lit.Library, error) {
+	mws, err := Stack()
+	if err != nil {
+		return nil, err
+	}
+	decorated := make([]lit.Library, len(clients))
+	for i, v := range clients {
+		decorated[i] = middleware.Chain(v, mws...)
+	}
+	return decorated, nil
+}
@@ -7,11 +7,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jecoz/lit/bibtex"
-	"golang.org/x/sync/errgroup"
 )
 
 func marshal(s string) (string, error) {
@@ -54,7 +56,7 @@ type Abstract struct {
 }
 
 func (a Abstract) GetText() string {
-	return strings.ReplaceAll(a.Text, "\n", "")
+	return strings.ReplaceAll(a.Text, "\n", " ")
 }
 
 func (a Abstract) Marshal() (string, error) {
@@ -125,11 +127,38 @@ func (k *Keywords) Unmarshal(data string) error {
 	return json.NewDecoder(strings.NewReader(d)).Decode(k)
 }
 
+// Keys of Publication.Values shared among libraries, allowing
+// publications to be enriched with data coming from a different one.
+const (
+	KeyDOI             = "doi"
+	KeyIssn            = "issn"
+	KeyAuthors         = "authors"
+	KeyPublisher       = "publisher"
+	KeyPublicationName = "publication_name"
+	KeyVolume          = "volume"
+	KeyIssue           = "issue"
+	KeyPageRange       = "page_range"
+	KeyLinkAbstract    = "link_abstract"
+)
+
 type Publication struct {
 	Title     string    `json:"title"`
 	CoverDate time.Time `json:"cover_date"`
 	Creator   string    `json:"creator"`
 
+	// The metadata libraries have in common, set as far as the library
+	// knows it.
+	Authors        []Author     `json:"authors,omitempty"`
+	IDs            Identifiers  `json:"ids"`
+	Venue          string       `json:"venue,omitempty"`
+	Volume         string       `json:"volume,omitempty"`
+	Issue          string       `json:"issue,omitempty"`
+	Pages          string       `json:"pages,omitempty"`
+	Type           DocumentType `json:"type,omitempty"`
+	Language       string       `json:"language,omitempty"` // As reported, e.g. "en" or "eng".
+	CitedBy        *int         `json:"cited_by,omitempty"`
+	SourceKeywords []string     `json:"source_keywords,omitempty"`
+
 	// Misc stuff used by clients to accomplish Library interface.
 	Values map[string]string `json:"values"`
 
@@ -138,8 +167,14 @@ type Publication struct {
 	*Keywords `json:"keywords,omitempty"`
 }
 
+// GetAbstract sets the abstract of p, looked up by lib, which must be an
+// AbstractProvider.
 func (p *Publication) GetAbstract(ctx context.Context, lib Library) error {
-	abs, err := lib.GetAbstract(ctx, *p)
+	var ap AbstractProvider
+	if !As(lib, &ap) {
+		return fmt.Errorf("%s: abstracts: %w", lib.GetName(), ErrUnsupported)
+	}
+	abs, err := ap.GetAbstract(ctx, *p)
 	if err != nil {
 		return err
 	}
@@ -147,9 +182,22 @@ func (p *Publication) GetAbstract(ctx context.Context, lib Library) error {
 	return nil
 }
 
+// Provenance tells which library, query and page produced a blob.
+type Provenance struct {
+	Library string
+	Query   string
+	Page    int
+}
+
+// Hit is a blob as delivered by a search, along with its provenance.
+type Hit struct {
+	Blob
+	Provenance
+}
+
 type BlobChan struct {
 	// queue of publications. Closed when no more will be delivered.
-	queue chan Blob
+	queue chan Hit
 
 	// Once the Chan is open, max tells the number of publications to
 	// expect from it.
@@ -159,12 +207,12 @@ type BlobChan struct {
 	err error
 }
 
-func (c *BlobChan) Recv() <-chan Blob {
+func (c *BlobChan) Recv() <-chan Hit {
 	return c.queue
 }
 
-func (c *BlobChan) Send(p Blob) error {
-	c.queue <- p
+func (c *BlobChan) Send(h Hit) error {
+	c.queue <- h
 	return nil
 }
 
@@ -183,7 +231,7 @@ func (c *BlobChan) Err() error {
 
 func NewBlobChan(max, queueLen int) *BlobChan {
 	return &BlobChan{
-		queue: make(chan Blob, queueLen),
+		queue: make(chan Hit, queueLen),
 		max:   max,
 	}
 }
@@ -194,8 +242,15 @@ type Request struct {
 	Page       int
 	PerPage    int
 	MaxResults int
+
+	// Cursor is used by libraries supporting cursor based pagination in
+	// place of Page. When empty, offset pagination is used instead. Use
+	// CursorStart to obtain the first page.
+	Cursor string
 }
 
+const CursorStart = "*"
+
 func (r Request) RoundsNeeded() int {
 	return int(math.Ceil(float64(r.MaxResults) / float64(r.PerPage)))
 }
@@ -219,6 +274,10 @@ func (r Request) String() string {
 type Response struct {
 	Req   Request
 	Blobs []Blob
+
+	// Next is the cursor pointing to the following page, when the
+	// library supports cursor based pagination.
+	Next string
 }
 
 func (r Response) Len() int {
@@ -229,6 +288,10 @@ func (r Response) IsEmpty() bool {
 	return r.Len() == 0
 }
 
+// Library is what every source of publications implements: searching and
+// parsing the results. What sources do besides is told by the optional
+// interfaces they implement, such as AbstractProvider or
+// CitationProvider, to be looked up through As.
 type Library interface {
 	GetName() string
 	GetRateLimit() time.Duration
@@ -238,37 +301,195 @@ type Library interface {
 	GetMaxLiterature(context.Context, Request) (int, error)
 	ParsePublication(Blob) (Publication, error)
 	PrettyPrint(Blob, *bytes.Buffer) error
+}
+
+// AbstractProvider is implemented by libraries looking up the abstracts
+// of their publications, when they do not come along with the results.
+type AbstractProvider interface {
 	GetAbstract(context.Context, Publication) (Abstract, error)
+}
 
+// BibTeXFormatter is implemented by libraries converting their
+// publications to BibTeX references, see ToBibTeX.
+type BibTeXFormatter interface {
 	ToBibTeX(Publication) bibtex.Reference
+}
+
+// CitationProvider is implemented by libraries knowing the citation graph
+// of their publications, used for snowballing.
+type CitationProvider interface {
+	// Citations returns the publications citing p.
+	Citations(context.Context, Publication) ([]Publication, error)
+	// References returns the publications cited by p.
+	References(context.Context, Publication) ([]Publication, error)
+}
+
+// FullTextProvider is implemented by libraries linking publications to
+// their full text, or to the page introducing it.
+type FullTextProvider interface {
+	// ReferenceLink returns the link to p, empty when there is none.
 	ReferenceLink(Publication) string
 }
 
-func searchLoop(ctx context.Context, blobChan *BlobChan, lib Library, req Request) {
+// ToBibTeX returns the reference of p, converted by lib when it is a
+// BibTeXFormatter, derived from its typed metadata otherwise: an article
+// or a conference paper when its venue is known, a misc entry when not.
+func ToBibTeX(lib Library, p Publication) bibtex.Reference {
+	var f BibTeXFormatter
+	if As(lib, &f) {
+		return f.ToBibTeX(p)
+	}
+	e := p.BibTeXEntry()
+	optional := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+	switch {
+	case p.Venue != "" && (p.Type == TypeArticle || p.Type == TypeReview):
+		return bibtex.Article{
+			Entry:     e,
+			Journal:   p.Venue,
+			Volume:    optional(p.Volume),
+			Number:    optional(p.Issue),
+			PageRange: optional(p.Pages),
+		}
+	case p.Venue != "" && p.Type == TypeConferencePaper:
+		return bibtex.InProceedings{
+			Entry:     e,
+			BookTitle: p.Venue,
+			PageRange: optional(p.Pages),
+		}
+	}
+	m := bibtex.Misc{Entry: e}
+	if p.IDs.ArXiv != "" {
+		m.Eprint, m.ArchivePrefix = optional(p.IDs.ArXiv), optional("arXiv")
+	}
+	return m
+}
+
+// CursorPager is implemented by libraries supporting cursor based
+// pagination, see Request.Cursor.
+type CursorPager interface {
+	// OffsetLimit returns the number of results reachable through offset
+	// pagination. Past it, cursors must be used.
+	OffsetLimit() int
+}
+
+// HTTPLibrary is implemented by libraries talking HTTP, exposing the client
+// they use so that its transport can be decorated, e.g. with a cache.
+type HTTPLibrary interface {
+	HTTPClient() *http.Client
+}
+
+// send delivers the blobs of resp to blobChan.
+func send(blobChan *BlobChan, lib Library, resp Response) {
+	for _, blob := range resp.Blobs {
+		blobChan.Send(Hit{
+			Blob: blob,
+			Provenance: Provenance{
+				Library: lib.GetName(),
+				Query:   resp.Req.Query,
+				Page:    resp.Req.Page,
+			},
+		})
+	}
+}
+
+// cursorLoop downloads the pages of req one after the other, each one
+// pointed by the cursor returned with the previous one. Pages in skip are
+// requested anyway, as they lead to the following ones, but their blobs
+// are not delivered. The first failing page ends the loop.
+func cursorLoop(ctx context.Context, blobChan *BlobChan, lib Library, req Request, skip map[int]bool) error {
+	cursor := CursorStart
+	for i := 0; i < req.RoundsNeeded(); i++ {
+		r := req.CloneWithPage(i)
+		r.Cursor = cursor
+		resp, err := getPage(ctx, lib, r, DefaultRetryPolicy)
+		if err != nil {
+			return fmt.Errorf("get literature: %w", err)
+		}
+		// Libraries might not set it.
+		resp.Req = r
+		if !skip[i] {
+			send(blobChan, lib, resp)
+		}
+		if resp.Next == "" || resp.IsEmpty() {
+			return nil
+		}
+		cursor = resp.Next
+	}
+	return nil
+}
+
+// searchLoop downloads the pages of req not in skip, using as many
+// workers as the library allows and retrying the pages failing with
+// temporary errors. Pages that fail anyway do not stop the others: their
+// errors are returned once all pages were tried. Results past the offset
+// limit of a CursorPager are downloaded sequentially through cursors.
+// Requests are not spaced: wrap lib with middleware.RateLimit to honor its
+// rate limit.
+func searchLoop(ctx context.Context, blobChan *BlobChan, lib Library, req Request, skip map[int]bool) error {
+	var p CursorPager
+	if As(lib, &p) && req.MaxResults > p.OffsetLimit() {
+		return cursorLoop(ctx, blobChan, lib, req, skip)
+	}
+
 	requests := make(chan Request, req.RoundsNeeded())
 	for i := 0; i < req.RoundsNeeded(); i++ {
+		if skip[i] {
+			continue
+		}
 		requests <- req.CloneWithPage(i)
 	}
 	close(requests)
 
-	limiter := time.Tick(lib.GetRateLimit())
-
-	g, ctx := errgroup.WithContext(ctx)
-	for r := range requests {
-		<-limiter
-		r := r
-		g.Go(func() error {
-			resp, err := lib.GetLiterature(ctx, r)
-			if err != nil {
-				return fmt.Errorf("get literature: page %d: %w", r.Page, err)
-			}
-			for _, blob := range resp.Blobs {
-				blobChan.Send(blob)
+	workers := 1
+	var l ConcurrencyLimiter
+	if As(lib, &l) && l.ConcurrencyLimit() > 1 {
+		workers = l.ConcurrencyLimit()
+	}
+	policy := DefaultRetryPolicy
+
+	var (
+		mu   sync.Mutex
+		errs []*PageError
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range requests {
+				if ctx.Err() != nil {
+					return
+				}
+				resp, err := getPage(ctx, lib, r, policy)
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err.(*PageError))
+					mu.Unlock()
+					continue
+				}
+				resp.Req = r
+				send(blobChan, lib, resp)
 			}
-			return nil
-		})
+		}()
 	}
-	blobChan.CloseWithError(g.Wait())
+	wg.Wait()
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Page < errs[j].Page
+	})
+	all := make([]error, 0, len(errs)+1)
+	if err := ctx.Err(); err != nil {
+		all = append(all, err)
+	}
+	for _, v := range errs {
+		all = append(all, fmt.Errorf("get literature: %w", v))
+	}
+	return joinErrors(all)
 }
 
 func GetLiterature(ctx context.Context, blobChan *BlobChan, lib Library, req Request) {
@@ -277,5 +498,91 @@ func GetLiterature(ctx context.Context, blobChan *BlobChan, lib Library, req Req
 		req.PerPage = lib.DefaultPerPage()
 	}
 
-	searchLoop(ctx, blobChan, lib, req)
+	blobChan.CloseWithError(searchLoop(ctx, blobChan, lib, req, nil))
+}
+
+// Source is a library taking part in a federated search, along with the
+// number of results to download from it.
+type Source struct {
+	Library Library
+	Max     int
+
+	// Query, when set, replaces the one of the request, e.g. with one of
+	// its slices, see SliceByYear.
+	Query string
+
+	// Skip holds the pages not to be downloaded, for example as a
+	// previous session already stored them.
+	Skip map[int]bool
+}
+
+// Requests returns the number of requests downloading s takes, retries
+// aside, with the default page size of its library.
+func (s Source) Requests() int {
+	req := Request{MaxResults: s.Max, PerPage: s.Library.DefaultPerPage()}
+	n := req.RoundsNeeded()
+	var p CursorPager
+	if As(s.Library, &p) && s.Max > p.OffsetLimit() {
+		// Skipped pages are requested anyway.
+		return n
+	}
+	for page, skip := range s.Skip {
+		if skip && page < req.RoundsNeeded() {
+			n--
+		}
+	}
+	return n
+}
+
+// GetFederatedLiterature runs req against all sources concurrently,
+// delivering their hits to blobChan. A library failing does not stop the
+// others: the errors of all of them are reported by blobChan once closed.
+func GetFederatedLiterature(ctx context.Context, blobChan *BlobChan, req Request, sources ...Source) {
+	errs := make([]error, len(sources))
+
+	var wg sync.WaitGroup
+	for i, v := range sources {
+		req := req
+		req.MaxResults = v.Max
+		if v.Query != "" {
+			req.Query = v.Query
+		}
+		if req.PerPage <= 0 {
+			req.PerPage = v.Library.DefaultPerPage()
+		}
+
+		wg.Add(1)
+		go func(i int, v Source) {
+			defer wg.Done()
+			if err := searchLoop(ctx, blobChan, v.Library, req, v.Skip); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", v.Library.GetName(), err)
+			}
+		}(i, v)
+	}
+	wg.Wait()
+
+	blobChan.CloseWithError(joinErrors(errs))
+}
+
+// joinErrors returns the first non nil error of errs, mentioning the
+// other ones in its message.
+func joinErrors(errs []error) error {
+	var (
+		first error
+		msgs  []string
+	)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if first == nil {
+			first = err
+			continue
+		}
+		msgs = append(msgs, err.Error())
+	}
+	if first == nil || len(msgs) == 0 {
+		return first
+	}
+	return fmt.Errorf("%w; %s", first, strings.Join(msgs, "; "))
 }
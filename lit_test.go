@@ -0,0 +1,440 @@
+package lit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jecoz/lit/bibtex"
+)
+
+type flakyLibrary struct {
+	Library
+
+	limit    int
+	failures map[int]error // page -> error returned
+	retries  map[int]int   // page -> times it fails before succeeding
+
+	mu       sync.Mutex
+	calls    map[int]int
+	inFlight int
+	maxSeen  int
+}
+
+func (l *flakyLibrary) GetName() string                       { return "flaky" }
+func (l *flakyLibrary) GetRateLimit() time.Duration           { return time.Millisecond }
+func (l *flakyLibrary) DefaultPerPage() int                   { return 10 }
+func (l *flakyLibrary) ConcurrencyLimit() int                 { return l.limit }
+func (l *flakyLibrary) PrettyPrint(Blob, *bytes.Buffer) error { return nil }
+
+func (l *flakyLibrary) GetLiterature(ctx context.Context, r Request) (Response, error) {
+	l.mu.Lock()
+	l.calls[r.Page]++
+	calls := l.calls[r.Page]
+	l.inFlight++
+	if l.inFlight > l.maxSeen {
+		l.maxSeen = l.inFlight
+	}
+	l.mu.Unlock()
+
+	time.Sleep(time.Millisecond * 5)
+
+	l.mu.Lock()
+	l.inFlight--
+	l.mu.Unlock()
+
+	if err, ok := l.failures[r.Page]; ok && calls <= l.retries[r.Page] {
+		return Response{}, err
+	}
+	blobs := []Blob{}
+	for i := r.Page * r.PerPage; i < (r.Page+1)*r.PerPage && i < r.MaxResults; i++ {
+		blobs = append(blobs, Blob(fmt.Sprintf("%d", i)))
+	}
+	return Response{Req: r, Blobs: blobs}, nil
+}
+
+func TestGetLiteratureRetries(t *testing.T) {
+	policy := DefaultRetryPolicy
+	DefaultRetryPolicy = RetryPolicy{Retries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond * 4}
+	defer func() { DefaultRetryPolicy = policy }()
+
+	unavailable := &StatusError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}
+	badRequest := &StatusError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}
+	lib := &flakyLibrary{
+		limit: 3,
+		failures: map[int]error{
+			1: unavailable, // recovers at the third attempt.
+			2: badRequest,  // never retried.
+			3: unavailable, // never recovers.
+		},
+		retries: map[int]int{1: 2, 2: 100, 3: 100},
+		calls:   make(map[int]int),
+	}
+
+	blobChan := NewBlobChan(95, 0)
+	go GetLiterature(context.Background(), blobChan, lib, Request{})
+	n := 0
+	for range blobChan.Recv() {
+		n++
+	}
+	if want := 95 - 20; n != want {
+		t.Errorf("have %d blobs, want %d", n, want)
+	}
+
+	err := blobChan.Err()
+	var pe *PageError
+	if !errors.As(err, &pe) || pe.Page != 2 || pe.Attempts != 1 || !errors.Is(err, badRequest) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if have := lib.calls[1]; have != 3 {
+		t.Errorf("page 1: have %d calls, want 3", have)
+	}
+	if have := lib.calls[3]; have != 3 {
+		t.Errorf("page 3: have %d calls, want 3", have)
+	}
+	if lib.maxSeen > lib.limit {
+		t.Errorf("have %d requests in flight, want at most %d", lib.maxSeen, lib.limit)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	lib := &flakyLibrary{}
+	p := Publication{
+		Title:     "Can FPGAs beat GPUs?",
+		Creator:   "Nurvitadhi",
+		CoverDate: time.Date(2017, time.February, 22, 0, 0, 0, 0, time.UTC),
+		Values:    map[string]string{KeyDOI: "10.1145/3020078.3021740"},
+	}
+	ref := ToBibTeX(lib, p)
+	if ref.EntryType() != bibtex.EntryTypeMisc || ref.Fields()["doi"] != p.Values[KeyDOI] || ref.Fields()["year"] != "2017" {
+		t.Errorf("unexpected reference %v", ref.Fields())
+	}
+	if err := p.GetAbstract(context.Background(), lib); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("have %v, want %v", err, ErrUnsupported)
+	}
+
+	p.Authors = []Author{{Name: "Nurvitadhi, Eriko"}, {Name: "Venkatesh, Ganesh"}}
+	p.Venue, p.Pages, p.Type = "Proceedings of FPGA '17", "5-14", TypeConferencePaper
+	ref = ToBibTeX(lib, p)
+	if f := ref.Fields(); ref.EntryType() != bibtex.EntryTypeInProceedings || f["booktitle"] != p.Venue || f["author"] != "Nurvitadhi, Eriko and Venkatesh, Ganesh" {
+		t.Errorf("unexpected reference %v %v", ref.EntryType(), f)
+	}
+}
+
+func TestBibTeXEntry(t *testing.T) {
+	p := Publication{
+		Title:   "Can FPGAs beat GPUs?",
+		Authors: []Author{{Name: "Nurvitadhi, Eriko"}},
+		IDs:     Identifiers{DOI: "10.1145/3020078.3021740"},
+		Review:  &Review{RejectReason: "out of scope"},
+		Values:  map[string]string{KeyIssn: ""},
+	}
+	e := p.BibTeXEntry()
+	if e.Author != "Nurvitadhi, Eriko" || e.DOI == nil || *e.DOI != p.IDs.DOI || e.Issn != nil || e.Url != nil {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if e.RejectReason == nil || *e.RejectReason != "out of scope" {
+		t.Errorf("have reject reason %v, want %q", e.RejectReason, "out of scope")
+	}
+
+	p.Values[KeyAuthors] = "Nurvitadhi, Eriko and Venkatesh, Ganesh"
+	p.Review.IsAccepted = true
+	if e := p.BibTeXEntry(); e.Author != p.Values[KeyAuthors] || e.RejectReason != nil {
+		t.Errorf("unexpected entry %+v", e)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	cited := 42
+	p := Publication{
+		Title:   "Can FPGAs beat GPUs?",
+		Creator: "Nurvitadhi E.",
+		IDs:     Identifiers{EID: "2-s2.0-85016079383"},
+		Pages:   "5-14",
+	}
+	if p.AuthorNames() != "Nurvitadhi E." {
+		t.Errorf("have author names %q", p.AuthorNames())
+	}
+	p.Complete(Publication{
+		Authors: []Author{{Name: "Nurvitadhi, Eriko", ORCID: "0000-0002-1825-0097"}},
+		IDs:     Identifiers{DOI: "10.1145/3020078.3021740", EID: "2-s2.0-0"},
+		Venue:   "FPGA",
+		Pages:   "5",
+		Type:    TypeConferencePaper,
+		CitedBy: &cited,
+	})
+	if p.IDs != (Identifiers{DOI: "10.1145/3020078.3021740", EID: "2-s2.0-85016079383"}) || p.Pages != "5-14" || p.Venue != "FPGA" {
+		t.Errorf("unexpected publication %+v", p)
+	}
+	if p.Type != TypeConferencePaper || p.CitedBy == nil || *p.CitedBy != 42 || p.AuthorNames() != "Nurvitadhi, Eriko" {
+		t.Errorf("unexpected publication %+v", p)
+	}
+	if p.DOI() != "10.1145/3020078.3021740" {
+		t.Errorf("have DOI %q", p.DOI())
+	}
+}
+
+func TestIsTemporary(t *testing.T) {
+	tt := []struct {
+		err  error
+		want bool
+	}{
+		{&StatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{&StatusError{StatusCode: http.StatusBadGateway}, true},
+		{&StatusError{StatusCode: http.StatusUnauthorized}, false},
+		{&StatusError{StatusCode: http.StatusTooManyRequests, Kind: ErrQuotaExceeded}, false},
+		{Malformed(io.ErrUnexpectedEOF), true},
+		{fmt.Errorf("wrapped: %w", &StatusError{StatusCode: http.StatusServiceUnavailable}), true},
+		{context.Canceled, false},
+		{errors.New("unknown"), false},
+	}
+	for _, v := range tt {
+		if have := IsTemporary(v.err); have != v.want {
+			t.Errorf("%v: have %v, want %v", v.err, have, v.want)
+		}
+	}
+}
+
+func TestHint(t *testing.T) {
+	r := &http.Response{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests", Header: http.Header{}}
+	r.Header.Set("Retry-After", "30")
+	tt := []struct {
+		err  error
+		want string
+	}{
+		{NewStatusError(r, ""), "rate limited, retry in 30s"},
+		{fmt.Errorf("page 1: %w", &StatusError{StatusCode: http.StatusForbidden, Kind: ErrNotEntitled}), "API key lacks entitlement"},
+		{fmt.Errorf("decode: %w", Malformed(errors.New("bad json"))), "unexpected response, the API might have changed"},
+		{errors.New("unknown"), ""},
+	}
+	for _, v := range tt {
+		if have := Hint(v.err); have != v.want {
+			t.Errorf("%v: have %q, want %q", v.err, have, v.want)
+		}
+	}
+	if have := Describe(tt[1].err); have != "API key lacks entitlement: page 1: " {
+		t.Errorf("unexpected description %q", have)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, time.May, 1, 10, 0, 0, 0, time.UTC)
+	for v, want := range map[string]time.Duration{
+		"":                              0,
+		"30":                            time.Second * 30,
+		"Sat, 01 May 2021 10:01:00 GMT": time.Minute,
+		"Sat, 01 May 2021 09:00:00 GMT": 0,
+		"soon":                          0,
+	} {
+		if have := parseRetryAfter(v, now); have != want {
+			t.Errorf("%q: have %v, want %v", v, have, want)
+		}
+	}
+}
+
+type cursorLibrary struct {
+	Library
+
+	max     int
+	cursors []string
+}
+
+func (l *cursorLibrary) GetName() string             { return "cursor" }
+func (l *cursorLibrary) GetRateLimit() time.Duration { return time.Millisecond }
+func (l *cursorLibrary) OffsetLimit() int            { return 10 }
+
+func (l *cursorLibrary) GetLiterature(ctx context.Context, r Request) (Response, error) {
+	if r.Cursor == "" {
+		return Response{}, fmt.Errorf("offset paging past the limit")
+	}
+	l.cursors = append(l.cursors, r.Cursor)
+	start := 0
+	if r.Cursor != CursorStart {
+		fmt.Sscanf(r.Cursor, "after-%d", &start)
+	}
+	blobs := []Blob{}
+	for i := start; i < start+r.PerPage && i < l.max; i++ {
+		blobs = append(blobs, Blob(fmt.Sprintf("%d", i)))
+	}
+	return Response{Blobs: blobs, Next: fmt.Sprintf("after-%d", start+len(blobs))}, nil
+}
+
+func TestGetFederatedLiteratureCursor(t *testing.T) {
+	lib := &cursorLibrary{max: 23}
+	blobChan := NewBlobChan(23, 0)
+	go GetFederatedLiterature(context.Background(), blobChan, Request{PerPage: 5}, Source{
+		Library: lib,
+		Max:     23,
+		Skip:    map[int]bool{1: true},
+	})
+	pages := make(map[int]int)
+	for h := range blobChan.Recv() {
+		pages[h.Page]++
+	}
+	if err := blobChan.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int]int{0: 5, 2: 5, 3: 5, 4: 3}; fmt.Sprint(pages) != fmt.Sprint(want) {
+		t.Errorf("have pages %v, want %v", pages, want)
+	}
+	want := []string{CursorStart, "after-5", "after-10", "after-15", "after-20"}
+	if fmt.Sprint(lib.cursors) != fmt.Sprint(want) {
+		t.Errorf("have cursors %v, want %v", lib.cursors, want)
+	}
+}
+
+type yearLibrary struct {
+	Library
+
+	limit   int
+	years   map[int]int
+	undated int
+}
+
+func (l yearLibrary) GetName() string             { return "years" }
+func (l yearLibrary) GetRateLimit() time.Duration { return time.Microsecond }
+func (l yearLibrary) ResultLimit() int            { return l.limit }
+
+func (l yearLibrary) SliceQuery(query string, from, to int) string {
+	return fmt.Sprintf("%s|%d|%d", query, from, to)
+}
+
+func (l yearLibrary) GetMaxLiterature(ctx context.Context, r Request) (int, error) {
+	var (
+		q        string
+		from, to int
+	)
+	if _, err := fmt.Sscanf(strings.ReplaceAll(r.Query, "|", " "), "%s %d %d", &q, &from, &to); err != nil {
+		n := l.undated
+		for _, v := range l.years {
+			n += v
+		}
+		return n, nil
+	}
+	n := 0
+	for y, v := range l.years {
+		if (from == 0 || y >= from) && (to == 0 || y <= to) {
+			n += v
+		}
+	}
+	return n, nil
+}
+
+func TestSliceByYear(t *testing.T) {
+	lib := yearLibrary{
+		limit: 100,
+		years: map[int]int{1960: 3, 1999: 40, 2000: 70, 2001: 20, 2015: 150, 2020: 99},
+	}
+	total, _ := lib.GetMaxLiterature(context.Background(), Request{Query: "q"})
+	slices, err := SliceByYear(context.Background(), lib, "q", total)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := 0
+	for _, v := range slices {
+		if v.Max > lib.limit && v.From != v.To {
+			t.Errorf("slice %v holds %d hits, over the limit", v, v.Max)
+		}
+		if v.Query != lib.SliceQuery("q", v.From, v.To) {
+			t.Errorf("slice %v: unexpected query %q", v, v.Query)
+		}
+		sum += v.Max
+	}
+	if sum != total {
+		t.Errorf("slices hold %d hits, want %d", sum, total)
+	}
+	if first := slices[0]; first.From != 0 || first.Max != 3 {
+		t.Errorf("unexpected first slice %v with %d hits", first, first.Max)
+	}
+
+	lib.undated = 5
+	_, err = SliceByYear(context.Background(), lib, "q", total+5)
+	var serr *SliceError
+	if !errors.As(err, &serr) || serr.Sum != total || serr.Total != total+5 {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestParseQuota(t *testing.T) {
+	now := time.Now()
+	h := http.Header{}
+	if _, ok := ParseQuota(h, now); ok {
+		t.Fatal("quota parsed out of no headers")
+	}
+	h.Set("X-RateLimit-Limit", "20000")
+	h.Set("X-RateLimit-Remaining", "12340")
+	h.Set("X-RateLimit-Reset", "1620900000")
+	q, ok := ParseQuota(h, now)
+	if !ok {
+		t.Fatal("quota not parsed")
+	}
+	if q.Limit != 20000 || q.Remaining != 12340 || q.Reset.Unix() != 1620900000 {
+		t.Errorf("unexpected quota %+v", q)
+	}
+	if have := q.At(q.Reset); have.Remaining != q.Limit {
+		t.Errorf("have %d requests left after the reset, want %d", have.Remaining, q.Limit)
+	}
+	if have := q.String(); !strings.HasPrefix(have, "12,340 of 20,000 requests left, resets ") {
+		t.Errorf("unexpected description %q", have)
+	}
+
+	name, have, err := UnmarshalQuota(q.Marshal("scopus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "scopus" || !have.Equal(q) || have.Time.Unix() != now.Unix() {
+		t.Errorf("have %s %+v, want %+v", name, have, q)
+	}
+}
+
+type quotaLibrary struct {
+	Library
+
+	quota Quota
+}
+
+func (l *quotaLibrary) GetName() string      { return "quota" }
+func (l *quotaLibrary) DefaultPerPage() int  { return 10 }
+func (l *quotaLibrary) Quota() (Quota, bool) { return l.quota, true }
+
+type quotaCursorLibrary struct {
+	quotaLibrary
+}
+
+func (l *quotaCursorLibrary) OffsetLimit() int { return 10 }
+
+func TestCheckQuota(t *testing.T) {
+	lib := &quotaLibrary{quota: Quota{Limit: 100, Remaining: 2, Reset: time.Now().Add(time.Hour)}}
+	sources := []Source{
+		{Library: lib, Max: 30, Skip: map[int]bool{0: true}},
+		{Library: lib, Max: 5},
+	}
+	if have := sources[0].Requests(); have != 2 {
+		t.Errorf("have %d requests, want 2", have)
+	}
+	// Past the offset limit, skipped pages are requested anyway.
+	cursor := &quotaCursorLibrary{}
+	if have := (Source{Library: cursor, Max: 25, Skip: map[int]bool{0: true}}).Requests(); have != 3 {
+		t.Errorf("have %d cursor requests, want 3", have)
+	}
+
+	err := CheckQuota(sources...)
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("unexpected error %v", err)
+	}
+	lib.quota.Remaining = 3
+	if err := CheckQuota(sources...); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	lib.quota = Quota{Limit: 100, Remaining: 0, Reset: time.Now().Add(-time.Minute)}
+	if err := CheckQuota(sources...); err != nil {
+		t.Errorf("quota reset, unexpected error %v", err)
+	}
+}
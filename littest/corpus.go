@@ -0,0 +1,40 @@
+package littest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Records returns n records made out of the JSON objects of fixture, for
+// fakes serving more results than were recorded. The i-th record is a copy
+// of fixture[i%len(fixture)] changed by vary, which is expected to make it
+// a publication of its own, e.g. by setting its identifiers.
+func Records(n int, fixture []json.RawMessage, vary func(i int, record map[string]interface{})) []json.RawMessage {
+	records := make([]json.RawMessage, n)
+	for i := range records {
+		var record map[string]interface{}
+		if err := json.Unmarshal(fixture[i%len(fixture)], &record); err != nil {
+			panic(fmt.Sprintf("littest: fixture %d: %v", i%len(fixture), err))
+		}
+		vary(i, record)
+		b, err := json.Marshal(record)
+		if err != nil {
+			panic(fmt.Sprintf("littest: record %d: %v", i, err))
+		}
+		records[i] = b
+	}
+	return records
+}
+
+// Page returns the records a search API serves from offset, at most limit
+// of them.
+func Page(records []json.RawMessage, offset, limit int) []json.RawMessage {
+	if offset < 0 || offset > len(records) {
+		offset = len(records)
+	}
+	end := offset + limit
+	if limit < 0 || end > len(records) {
+		end = len(records)
+	}
+	return records[offset:end]
+}
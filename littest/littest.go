@@ -0,0 +1,232 @@
+// Package littest checks that lit.Library implementations behave the way
+// the rest of lit expects them to. Adapters run RunConformance against a
+// fake of their API, or a fixture of their own:
+//
+//	func TestConformance(t *testing.T) {
+//		littest.RunConformance(t, newTestClient(t), littest.Fixture{
+//			Query:   "fpga",
+//			PerPage: 10,
+//			Max:     42,
+//		})
+//	}
+package littest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+)
+
+// Fixture describes the results a library is expected to have.
+type Fixture struct {
+	// Query is searched for. Pick one spanning a few pages of PerPage
+	// results, so that paging is put to test.
+	Query string
+	// PerPage is the size of the pages requested, DefaultPerPage when
+	// zero.
+	PerPage int
+	// Max is the number of results expected for Query, any positive
+	// number when zero.
+	Max int
+	// Limit bounds the number of results downloaded, useful with
+	// libraries serving more than a handful of pages. All of them are
+	// downloaded when zero.
+	Limit int
+}
+
+// requiredFields are the BibTeX fields, besides title, author and year,
+// required by each entry type.
+var requiredFields = map[bibtex.EntryType][]string{
+	bibtex.EntryTypeArticle:       {"journal"},
+	bibtex.EntryTypeBook:          {"publisher"},
+	bibtex.EntryTypeInCollection:  {"booktitle"},
+	bibtex.EntryTypeInProceedings: {"booktitle"},
+	bibtex.EntryTypeTechReport:    {"institution"},
+}
+
+// RunConformance checks, in subtests, that lib:
+//
+//   - counts the results of f.Query consistently, as f.Max when set;
+//   - serves them in full pages of the size requested, but for the last
+//     one, without duplicates, the same ones when a page is requested
+//     twice, and as many as counted through lit.GetLiterature;
+//   - parses them into publications with a title, the same ones once
+//     their blobs are stored and loaded back, and pretty prints them;
+//   - converts them to BibTeX references holding the fields their entry
+//     type requires, through lit.ToBibTeX, and links them to absolute
+//     HTTP URLs, if at all, when lib is a lit.FullTextProvider;
+//   - gives up requests once their context is canceled.
+func RunConformance(t *testing.T, lib lit.Library, f Fixture) {
+	t.Helper()
+	ctx := context.Background()
+	perPage := f.PerPage
+	if perPage <= 0 {
+		perPage = lib.DefaultPerPage()
+	}
+
+	max, err := lib.GetMaxLiterature(ctx, lit.Request{Query: f.Query})
+	if err != nil {
+		t.Fatalf("get max literature: %v", err)
+	}
+	total := max
+	if f.Limit > 0 && total > f.Limit {
+		total = f.Limit
+	}
+
+	var blobs []lit.Blob
+	t.Run("Max", func(t *testing.T) {
+		if max <= 0 || (f.Max > 0 && max != f.Max) {
+			t.Errorf("have %d results, want %d", max, f.Max)
+		}
+		again, err := lib.GetMaxLiterature(ctx, lit.Request{Query: f.Query})
+		if err != nil || again != max {
+			t.Errorf("counted %d results, then %d, %v", max, again, err)
+		}
+	})
+
+	t.Run("Paging", func(t *testing.T) {
+		req := lit.Request{Query: f.Query, PerPage: perPage, MaxResults: total}
+		for i := 0; i < req.RoundsNeeded(); i++ {
+			resp, err := lib.GetLiterature(ctx, req.CloneWithPage(i))
+			if err != nil {
+				t.Fatalf("page %d: %v", i, err)
+			}
+			want := max - i*perPage
+			if want > perPage {
+				want = perPage
+			}
+			if resp.Len() != want {
+				t.Errorf("page %d: have %d results, want %d", i, resp.Len(), want)
+			}
+			blobs = append(blobs, resp.Blobs...)
+		}
+
+		resp, err := lib.GetLiterature(ctx, req.CloneWithPage(0))
+		if err != nil {
+			t.Fatalf("page 0, again: %v", err)
+		}
+		for i, v := range resp.Blobs {
+			if i >= len(blobs) || !bytes.Equal(v, blobs[i]) {
+				t.Errorf("page 0, again: result %d differs", i)
+				break
+			}
+		}
+
+		seen := make(map[string]int)
+		for i, v := range blobs {
+			p, err := lib.ParsePublication(v)
+			if err != nil {
+				continue // Reported by ParsePublication.
+			}
+			id := lit.PublicationID(lib, p)
+			if j, ok := seen[id]; ok {
+				t.Errorf("results %d and %d are both %s", j, i, id)
+			}
+			seen[id] = i
+		}
+	})
+
+	t.Run("Delivered", func(t *testing.T) {
+		blobChan := lit.NewBlobChan(total, 0)
+		go lit.GetLiterature(ctx, blobChan, lib, lit.Request{Query: f.Query, PerPage: perPage})
+		n := 0
+		for range blobChan.Recv() {
+			n++
+		}
+		if err := blobChan.Err(); err != nil {
+			t.Fatal(err)
+		}
+		// Pages are delivered whole.
+		want := lit.Request{PerPage: perPage, MaxResults: total}.RoundsNeeded() * perPage
+		if want > max {
+			want = max
+		}
+		if n != want {
+			t.Errorf("delivered %d results, want %d", n, want)
+		}
+	})
+
+	var pubs []lit.Publication
+	t.Run("ParsePublication", func(t *testing.T) {
+		for i, v := range blobs {
+			p, err := lib.ParsePublication(v)
+			if err != nil {
+				t.Errorf("result %d: %v", i, err)
+				continue
+			}
+			if p.Title == "" {
+				t.Errorf("result %d: no title", i)
+			}
+			data, err := v.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var loaded lit.Blob
+			if err := loaded.Unmarshal(data); err != nil {
+				t.Fatal(err)
+			}
+			if q, err := lib.ParsePublication(loaded); err != nil || !reflect.DeepEqual(p, q) {
+				t.Errorf("result %d: stored and loaded back, parses to %+v, %v, want %+v", i, q, err, p)
+			}
+			var buf bytes.Buffer
+			if err := lib.PrettyPrint(v, &buf); err != nil || buf.Len() == 0 {
+				t.Errorf("result %d: pretty print: %v", i, err)
+			}
+			pubs = append(pubs, p)
+		}
+	})
+
+	t.Run("ToBibTeX", func(t *testing.T) {
+		for _, p := range pubs {
+			ref := lit.ToBibTeX(lib, p)
+			if ref == nil {
+				t.Errorf("%q: no reference", p.Title)
+				continue
+			}
+			if ref.EntryType() == "" || ref.CiteKey() == "" {
+				t.Errorf("%q: have entry type %q, cite key %q", p.Title, ref.EntryType(), ref.CiteKey())
+			}
+			fields := ref.Fields()
+			for _, k := range append([]string{"title", "author", "year"}, requiredFields[ref.EntryType()]...) {
+				if fields[k] == "" || (k == "year" && fields[k] == "0") {
+					t.Errorf("%q: %s entry without %s", p.Title, ref.EntryType(), k)
+				}
+			}
+		}
+	})
+
+	t.Run("ReferenceLink", func(t *testing.T) {
+		var links lit.FullTextProvider
+		if !lit.As(lib, &links) {
+			t.Skip("not a lit.FullTextProvider")
+		}
+		for _, p := range pubs {
+			link := links.ReferenceLink(p)
+			if link == "" {
+				continue
+			}
+			u, err := url.Parse(link)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				t.Errorf("%q: invalid link %q", p.Title, link)
+			}
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := lib.GetMaxLiterature(ctx, lit.Request{Query: f.Query}); !errors.Is(err, context.Canceled) {
+			t.Errorf("get max literature: have %v, want %v", err, context.Canceled)
+		}
+		req := lit.Request{Query: f.Query, PerPage: perPage, MaxResults: total}
+		if _, err := lib.GetLiterature(ctx, req); !errors.Is(err, context.Canceled) {
+			t.Errorf("get literature: have %v, want %v", err, context.Canceled)
+		}
+	})
+}
@@ -0,0 +1,280 @@
+// Package middleware decorates lit.Library implementations with features
+// that do not depend on the library, such as logging, rate limiting or
+// retrying failed requests, so that adapters do not implement them each
+// on their own.
+//
+// Decorated libraries implement lit.Wrapper: use lit.As to look up the
+// optional interfaces of the library decorated. Besides the requests of
+// lit.Library, middlewares see the ones of lit.AbstractProvider; those of
+// the other interfaces reach the library directly.
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/jecoz/lit"
+)
+
+// Middleware decorates a library.
+type Middleware func(lit.Library) lit.Library
+
+// Chain decorates lib with mws, the first one being the outermost.
+func Chain(lib lit.Library, mws ...Middleware) lit.Library {
+	for i := len(mws) - 1; i >= 0; i-- {
+		lib = mws[i](lib)
+	}
+	return lib
+}
+
+// Op names a method of lit.Library, or lit.AbstractProvider, making
+// requests.
+type Op string
+
+const (
+	OpGetLiterature    Op = "GetLiterature"
+	OpGetMaxLiterature Op = "GetMaxLiterature"
+	OpGetAbstract      Op = "GetAbstract"
+)
+
+// Call is a request made to a library.
+type Call struct {
+	Library lit.Library
+	Op      Op
+	// Request is set by OpGetLiterature and OpGetMaxLiterature,
+	// Publication by OpGetAbstract.
+	Request     lit.Request
+	Publication lit.Publication
+}
+
+func (c Call) String() string {
+	if c.Op == OpGetAbstract {
+		return fmt.Sprintf("%s %s %s", c.Library.GetName(), c.Op, lit.PublicationID(c.Library, c.Publication))
+	}
+	return fmt.Sprintf("%s %s %v", c.Library.GetName(), c.Op, c.Request)
+}
+
+// Handler runs c, calling next to make the actual request. It might call
+// it more than once, or not at all.
+type Handler func(ctx context.Context, c Call, next func(context.Context) error) error
+
+// Around returns a middleware running each request through h. Libraries
+// decorated are AbstractProviders when lib is one.
+func Around(h Handler) Middleware {
+	return func(lib lit.Library) lit.Library {
+		a := around{Library: lib, h: h}
+		var ap lit.AbstractProvider
+		if lit.As(lib, &ap) {
+			return aroundAbstracts{around: a, abstracts: ap}
+		}
+		return a
+	}
+}
+
+type around struct {
+	lit.Library
+	h Handler
+}
+
+func (a around) Unwrap() lit.Library {
+	return a.Library
+}
+
+func (a around) GetLiterature(ctx context.Context, r lit.Request) (lit.Response, error) {
+	var resp lit.Response
+	err := a.h(ctx, Call{Library: a.Library, Op: OpGetLiterature, Request: r}, func(ctx context.Context) error {
+		var err error
+		resp, err = a.Library.GetLiterature(ctx, r)
+		return err
+	})
+	return resp, err
+}
+
+func (a around) GetMaxLiterature(ctx context.Context, r lit.Request) (int, error) {
+	var n int
+	err := a.h(ctx, Call{Library: a.Library, Op: OpGetMaxLiterature, Request: r}, func(ctx context.Context) error {
+		var err error
+		n, err = a.Library.GetMaxLiterature(ctx, r)
+		return err
+	})
+	return n, err
+}
+
+type aroundAbstracts struct {
+	around
+	abstracts lit.AbstractProvider
+}
+
+func (a aroundAbstracts) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	var abs lit.Abstract
+	err := a.h(ctx, Call{Library: a.Library, Op: OpGetAbstract, Publication: p}, func(ctx context.Context) error {
+		var err error
+		abs, err = a.abstracts.GetAbstract(ctx, p)
+		return err
+	})
+	return abs, err
+}
+
+// Logging logs each request to l, along with its outcome and duration.
+func Logging(l *log.Logger) Middleware {
+	return Around(func(ctx context.Context, c Call, next func(context.Context) error) error {
+		start := time.Now()
+		err := next(ctx)
+		if err != nil {
+			l.Printf("%v: %v (%v)", c, err, time.Since(start))
+			return err
+		}
+		l.Printf("%v: ok (%v)", c, time.Since(start))
+		return nil
+	})
+}
+
+// RateLimit spaces the requests to the library by interval, or by its
+// GetRateLimit when interval is zero. Concurrent requests wait for their
+// turn.
+func RateLimit(interval time.Duration) Middleware {
+	return func(lib lit.Library) lit.Library {
+		l := &limiter{interval: interval}
+		if l.interval <= 0 {
+			l.interval = lib.GetRateLimit()
+		}
+		return Around(func(ctx context.Context, c Call, next func(context.Context) error) error {
+			if err := l.wait(ctx); err != nil {
+				return err
+			}
+			return next(ctx)
+		})(lib)
+	}
+}
+
+type limiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait blocks until the turn of the caller comes.
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	t := l.next
+	if t.Before(now) {
+		t = now
+	}
+	l.next = t.Add(l.interval)
+	l.mu.Unlock()
+
+	select {
+	case <-time.After(time.Until(t)):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Retry retries the requests failing with temporary errors according to
+// policy. GetLiterature is left alone, as lit.GetLiterature retries pages
+// on its own, reporting the attempts made.
+func Retry(policy lit.RetryPolicy) Middleware {
+	return Around(func(ctx context.Context, c Call, next func(context.Context) error) error {
+		if c.Op == OpGetLiterature {
+			return next(ctx)
+		}
+		_, err := policy.Do(ctx, func() error {
+			return next(ctx)
+		})
+		return err
+	})
+}
+
+// ErrInjected is the cause of the failures injected by Faults.
+var ErrInjected = &lit.StatusError{
+	StatusCode: 503,
+	Status:     "503 Service Unavailable",
+	Message:    "injected fault",
+}
+
+// Faults fails the given rate of requests, between 0 and 1, with
+// ErrInjected, a temporary error, without making them. Use it to check
+// how the commands cope with an unreliable library.
+func Faults(rate float64, seed int64) Middleware {
+	var (
+		mu  sync.Mutex
+		rnd = rand.New(rand.NewSource(seed))
+	)
+	return Around(func(ctx context.Context, c Call, next func(context.Context) error) error {
+		mu.Lock()
+		fail := rnd.Float64() < rate
+		mu.Unlock()
+		if fail {
+			return ErrInjected
+		}
+		return next(ctx)
+	})
+}
+
+// Stats sums up the requests made to a library.
+type Stats struct {
+	Requests int
+	Errors   int
+	// Latency is the total time spent waiting for responses.
+	Latency time.Duration
+}
+
+func (s Stats) String() string {
+	if s.Requests == 0 {
+		return "no requests"
+	}
+	return fmt.Sprintf("%d requests, %d failed, %v on average", s.Requests, s.Errors, (s.Latency / time.Duration(s.Requests)).Round(time.Millisecond))
+}
+
+// Measurer is implemented by the libraries decorated by Measure.
+type Measurer interface {
+	Stats() Stats
+}
+
+// Measure keeps Stats of the requests made to the library, available
+// through Measurer.
+func Measure() Middleware {
+	return func(lib lit.Library) lit.Library {
+		m := &measured{}
+		m.Library = Around(m.handle)(lib)
+		return m
+	}
+}
+
+type measured struct {
+	lit.Library
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+func (m *measured) Unwrap() lit.Library {
+	return m.Library
+}
+
+func (m *measured) handle(ctx context.Context, c Call, next func(context.Context) error) error {
+	start := time.Now()
+	err := next(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats.Requests++
+	m.stats.Latency += time.Since(start)
+	if err != nil {
+		m.stats.Errors++
+	}
+	return err
+}
+
+func (m *measured) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
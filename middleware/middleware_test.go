@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jecoz/lit"
+)
+
+type countingLibrary struct {
+	lit.Library
+
+	mu    sync.Mutex
+	calls map[Op]int
+	err   error
+}
+
+func (l *countingLibrary) count(op Op) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.calls == nil {
+		l.calls = make(map[Op]int)
+	}
+	l.calls[op]++
+	return l.err
+}
+
+func (l *countingLibrary) GetName() string             { return "counting" }
+func (l *countingLibrary) GetRateLimit() time.Duration { return time.Millisecond * 20 }
+func (l *countingLibrary) ResultLimit() int            { return 42 }
+
+func (l *countingLibrary) SliceQuery(query string, from, to int) string {
+	return fmt.Sprintf("%s %d-%d", query, from, to)
+}
+
+func (l *countingLibrary) GetLiterature(ctx context.Context, r lit.Request) (lit.Response, error) {
+	if err := l.count(OpGetLiterature); err != nil {
+		return lit.Response{}, err
+	}
+	return lit.Response{Req: r, Blobs: []lit.Blob{lit.Blob(fmt.Sprintf("page %d", r.Page))}}, nil
+}
+
+func (l *countingLibrary) GetMaxLiterature(ctx context.Context, r lit.Request) (int, error) {
+	if err := l.count(OpGetMaxLiterature); err != nil {
+		return 0, err
+	}
+	return len(r.Query), nil
+}
+
+func (l *countingLibrary) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	if err := l.count(OpGetAbstract); err != nil {
+		return lit.Abstract{}, err
+	}
+	return lit.Abstract{Text: "abstract of " + p.Title}, nil
+}
+
+// abstracts returns the AbstractProvider wrapped by lib.
+func abstracts(t *testing.T, lib lit.Library) lit.AbstractProvider {
+	t.Helper()
+	var ap lit.AbstractProvider
+	if !lit.As(lib, &ap) {
+		t.Fatal("abstract provider not found")
+	}
+	return ap
+}
+
+func TestChain(t *testing.T) {
+	var buf bytes.Buffer
+	inner := &countingLibrary{}
+	lib := Chain(inner, Measure(), Logging(log.New(&buf, "", 0)))
+
+	if _, err := lib.GetMaxLiterature(context.Background(), lit.Request{Query: "fpga"}); err != nil {
+		t.Fatal(err)
+	}
+	if have := buf.String(); !strings.HasPrefix(have, "counting GetMaxLiterature") {
+		t.Errorf("unexpected log %q", have)
+	}
+
+	// Optional interfaces are found past the wrappers.
+	var s lit.Slicer
+	if !lit.As(lib, &s) || s.ResultLimit() != 42 {
+		t.Errorf("slicer not found")
+	}
+	var m Measurer
+	if !lit.As(lib, &m) || m.Stats().Requests != 1 {
+		t.Errorf("measurer not found")
+	}
+	var q lit.QuotaReporter
+	if lit.As(lib, &q) {
+		t.Errorf("unexpected quota reporter")
+	}
+
+	// Wrappers do not add capabilities the library lacks.
+	var ap lit.AbstractProvider
+	if lib := Chain(struct{ lit.Library }{inner}, Retry(lit.RetryPolicy{}), Measure()); lit.As(lib, &ap) {
+		t.Errorf("unexpected abstract provider")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	lib := RateLimit(0)(&countingLibrary{})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			abstracts(t, lib).GetAbstract(context.Background(), lit.Publication{})
+		}()
+	}
+	wg.Wait()
+	if elapsed, want := time.Since(start), time.Millisecond*60; elapsed < want {
+		t.Errorf("4 requests took %v, want at least %v", elapsed, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := abstracts(t, lib).GetAbstract(ctx, lit.Publication{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	inner := &countingLibrary{err: ErrInjected}
+	lib := Retry(lit.RetryPolicy{Retries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})(inner)
+
+	if _, err := abstracts(t, lib).GetAbstract(context.Background(), lit.Publication{}); !errors.Is(err, ErrInjected) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := lib.GetLiterature(context.Background(), lit.Request{}); !errors.Is(err, ErrInjected) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if have := inner.calls[OpGetAbstract]; have != 3 {
+		t.Errorf("have %d abstract requests, want 3", have)
+	}
+	if have := inner.calls[OpGetLiterature]; have != 1 {
+		t.Errorf("have %d literature requests, want 1", have)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	inner := &countingLibrary{}
+	lib := Chain(inner, Measure(), Faults(0.5, 1))
+	for i := 0; i < 200; i++ {
+		lib.GetMaxLiterature(context.Background(), lit.Request{})
+	}
+	var m Measurer
+	lit.As(lib, &m)
+	stats := m.Stats()
+	if stats.Requests != 200 || stats.Errors+inner.calls[OpGetMaxLiterature] != 200 {
+		t.Fatalf("unexpected stats %+v, %d requests made", stats, inner.calls[OpGetMaxLiterature])
+	}
+	if stats.Errors < 60 || stats.Errors > 140 {
+		t.Errorf("have %d faults out of 200, want about 100", stats.Errors)
+	}
+}
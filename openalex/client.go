@@ -0,0 +1,606 @@
+package openalex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+)
+
+const endpoint = "https://api.openalex.org"
+
+const (
+	KeyID              = "id"
+	KeyLinkAbstract    = lit.KeyLinkAbstract
+	KeyDOI             = lit.KeyDOI
+	KeyIssn            = lit.KeyIssn
+	KeyPageRange       = lit.KeyPageRange
+	KeyVolume          = lit.KeyVolume
+	KeyIssue           = lit.KeyIssue
+	KeyPublicationName = lit.KeyPublicationName
+	KeySourceType      = "source_type"
+	KeyType            = "type"
+	KeyPublisher       = lit.KeyPublisher
+	KeyAuthors         = lit.KeyAuthors
+	KeyCitedByCount    = "cited_by_count"
+	KeyAffiliation     = "affiliation"
+)
+
+type Client struct {
+	mailto     string
+	endpoint   string
+	httpClient *http.Client
+}
+
+func (c Client) DefaultPerPage() int {
+	return 50
+}
+
+func (c Client) newRequest(ctx context.Context, path string, q url.Values) *http.Request {
+	u, err := url.Parse(c.endpoint + path)
+	if err != nil {
+		panic(err)
+	}
+	if q == nil {
+		q = url.Values{}
+	}
+	if c.mailto != "" {
+		q.Set("mailto", c.mailto)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	return req
+}
+
+func (c Client) newSearchRequest(ctx context.Context, src lit.Request) *http.Request {
+	q := url.Values{}
+	q.Set("search", src.Query)
+	q.Set("per-page", fmt.Sprintf("%d", src.PerPage))
+	if src.Cursor != "" {
+		q.Set("cursor", src.Cursor)
+	} else {
+		// OpenAlex pages start from 1.
+		q.Set("page", fmt.Sprintf("%d", src.Page+1))
+	}
+	return c.newRequest(ctx, "/works", q)
+}
+
+func extractError(r *http.Response) error {
+	var p struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&p); err == nil && p.Message != "" {
+		return lit.NewStatusError(r, p.Message)
+	}
+	return lit.NewStatusError(r, "")
+}
+
+type searchMeta struct {
+	Count      int    `json:"count"`
+	NextCursor string `json:"next_cursor"`
+}
+
+type searchResults struct {
+	Meta    searchMeta        `json:"meta"`
+	Results []json.RawMessage `json:"results"`
+}
+
+func (c Client) search(ctx context.Context, req lit.Request) (searchResults, error) {
+	resp, err := c.httpClient.Do(c.newSearchRequest(ctx, req))
+	if err != nil {
+		return searchResults{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return searchResults{}, extractError(resp)
+	}
+
+	var p searchResults
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return searchResults{}, err
+	}
+	return p, nil
+}
+
+func (c Client) GetLiterature(ctx context.Context, req lit.Request) (lit.Response, error) {
+	p, err := c.search(ctx, req)
+	if err != nil {
+		return lit.Response{}, err
+	}
+	blobs := make([]lit.Blob, len(p.Results))
+	for i, v := range p.Results {
+		blobs[i] = lit.Blob(v)
+	}
+	return lit.Response{
+		Req:   req,
+		Blobs: blobs,
+		Next:  p.Meta.NextCursor,
+	}, nil
+}
+
+func (c Client) GetMaxLiterature(ctx context.Context, req lit.Request) (int, error) {
+	req.Page = 0
+	req.PerPage = 1
+	p, err := c.search(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return p.Meta.Count, nil
+}
+
+// Facets breaks the results of req down by year of publication by
+// grouping works by publication_year, see lit.FacetProvider.
+func (c Client) Facets(ctx context.Context, req lit.Request, field string) ([]lit.Facet, error) {
+	if field != lit.FacetYear {
+		return nil, fmt.Errorf("facet %q: %w", field, lit.ErrUnsupported)
+	}
+	q := url.Values{}
+	q.Set("search", req.Query)
+	q.Set("group_by", "publication_year")
+	resp, err := c.httpClient.Do(c.newRequest(ctx, "/works", q))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, extractError(resp)
+	}
+
+	var p struct {
+		GroupBy []struct {
+			Key   string `json:"key"`
+			Count int    `json:"count"`
+		} `json:"group_by"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return nil, err
+	}
+	facets := make([]lit.Facet, len(p.GroupBy))
+	for i, v := range p.GroupBy {
+		facets[i] = lit.Facet{Value: v.Key, Count: v.Count}
+	}
+	sort.Slice(facets, func(i, j int) bool { return facets[i].Value < facets[j].Value })
+	return facets, nil
+}
+
+func (c Client) GetRateLimit() time.Duration {
+	// https://docs.openalex.org/how-to-use-the-api/rate-limits-and-authentication
+	return time.Millisecond * 1000 / time.Duration(10)
+}
+
+// OffsetLimit returns the number of results reachable through basic
+// paging, see lit.CursorPager.
+func (c Client) OffsetLimit() int {
+	return 10000
+}
+
+func (c Client) ConcurrencyLimit() int {
+	return 10
+}
+
+func (c Client) PrettyPrint(b lit.Blob, dst *bytes.Buffer) error {
+	return json.Indent(dst, []byte(b), "", "\t")
+}
+
+type author struct {
+	DisplayName string `json:"display_name"`
+	ORCID       string `json:"orcid"`
+}
+
+type institution struct {
+	DisplayName string `json:"display_name"`
+}
+
+type authorship struct {
+	Position     string        `json:"author_position"`
+	Author       author        `json:"author"`
+	Institutions []institution `json:"institutions"`
+}
+
+type source struct {
+	DisplayName string `json:"display_name"`
+	IssnL       string `json:"issn_l"`
+	Type        string `json:"type"`
+	HostOrgName string `json:"host_organization_name"`
+}
+
+type location struct {
+	Source         *source `json:"source"`
+	LandingPageURL string  `json:"landing_page_url"`
+}
+
+type biblio struct {
+	Volume    string `json:"volume"`
+	Issue     string `json:"issue"`
+	FirstPage string `json:"first_page"`
+	LastPage  string `json:"last_page"`
+}
+
+type work struct {
+	ID              string `json:"id"`
+	DOI             string `json:"doi"`
+	Title           string `json:"title"`
+	DisplayName     string `json:"display_name"`
+	PublicationYear int    `json:"publication_year"`
+	PublicationDate string `json:"publication_date"`
+	Type            string `json:"type"`
+	CitedByCount    int    `json:"cited_by_count"`
+	Language        string `json:"language"`
+	IDs             struct {
+		PMID string `json:"pmid"`
+	} `json:"ids"`
+	Keywords []struct {
+		DisplayName string `json:"display_name"`
+	} `json:"keywords"`
+	Authorships     []authorship     `json:"authorships"`
+	PrimaryLocation *location        `json:"primary_location"`
+	Biblio          biblio           `json:"biblio"`
+	InvertedIndex   map[string][]int `json:"abstract_inverted_index"`
+}
+
+func (w work) CoverDate() (time.Time, error) {
+	if w.PublicationDate != "" {
+		t, err := time.Parse("2006-01-02", w.PublicationDate)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse publication date: %w", err)
+		}
+		return t, nil
+	}
+	if w.PublicationYear != 0 {
+		return time.Date(w.PublicationYear, time.January, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Time{}, fmt.Errorf("parse publication date: no date available")
+}
+
+func (w work) GetTitle() string {
+	if w.Title != "" {
+		return w.Title
+	}
+	return w.DisplayName
+}
+
+func (w work) Creator() string {
+	for _, v := range w.Authorships {
+		if v.Position == "first" {
+			return v.Author.DisplayName
+		}
+	}
+	if len(w.Authorships) > 0 {
+		return w.Authorships[0].Author.DisplayName
+	}
+	return ""
+}
+
+func (w work) Authors() string {
+	names := make([]string, 0, len(w.Authorships))
+	for _, v := range w.Authorships {
+		if v.Author.DisplayName != "" {
+			names = append(names, v.Author.DisplayName)
+		}
+	}
+	return strings.Join(names, " and ")
+}
+
+// AuthorList returns the authors of the work, each with the names of
+// their institutions.
+func (w work) AuthorList() []lit.Author {
+	var authors []lit.Author
+	for _, v := range w.Authorships {
+		if v.Author.DisplayName == "" {
+			continue
+		}
+		institutions := make([]string, len(v.Institutions))
+		for i, inst := range v.Institutions {
+			institutions[i] = inst.DisplayName
+		}
+		authors = append(authors, lit.Author{
+			Name:        v.Author.DisplayName,
+			ORCID:       strings.TrimPrefix(v.Author.ORCID, "https://orcid.org/"),
+			Affiliation: strings.Join(institutions, ", "),
+		})
+	}
+	return authors
+}
+
+// DocumentType maps the type of the work onto the types of lit, telling
+// conference papers apart from articles through the type of their
+// source.
+func (w work) DocumentType() lit.DocumentType {
+	switch w.Type {
+	case "article", "proceedings-article":
+		if l := w.PrimaryLocation; w.Type == "proceedings-article" || (l != nil && l.Source != nil && l.Source.Type == "conference") {
+			return lit.TypeConferencePaper
+		}
+		return lit.TypeArticle
+	case "review":
+		return lit.TypeReview
+	case "book":
+		return lit.TypeBook
+	case "book-chapter":
+		return lit.TypeBookChapter
+	case "preprint", "posted-content":
+		return lit.TypePreprint
+	case "dissertation":
+		return lit.TypeThesis
+	case "report":
+		return lit.TypeReport
+	case "":
+		return ""
+	default:
+		return lit.TypeOther
+	}
+}
+
+func (w work) Affiliation() string {
+	seen := make(map[string]bool)
+	affiliations := []string{}
+	for _, a := range w.Authorships {
+		for _, v := range a.Institutions {
+			if v.DisplayName == "" || seen[v.DisplayName] {
+				continue
+			}
+			seen[v.DisplayName] = true
+			affiliations = append(affiliations, v.DisplayName)
+		}
+	}
+	return strings.Join(affiliations, "; ")
+}
+
+func (w work) PageRange() string {
+	b := w.Biblio
+	switch {
+	case b.FirstPage == "":
+		return ""
+	case b.LastPage == "" || b.LastPage == b.FirstPage:
+		return b.FirstPage
+	default:
+		return b.FirstPage + "-" + b.LastPage
+	}
+}
+
+func (w work) Link() string {
+	if l := w.PrimaryLocation; l != nil && l.LandingPageURL != "" {
+		return l.LandingPageURL
+	}
+	if w.DOI != "" {
+		return w.DOI
+	}
+	return w.ID
+}
+
+func (w work) Values() map[string]string {
+	var src source
+	if l := w.PrimaryLocation; l != nil && l.Source != nil {
+		src = *l.Source
+	}
+
+	return map[string]string{
+		KeyID:              strings.TrimPrefix(w.ID, "https://openalex.org/"),
+		KeyLinkAbstract:    w.Link(),
+		KeyDOI:             strings.TrimPrefix(w.DOI, "https://doi.org/"),
+		KeyIssn:            src.IssnL,
+		KeyPageRange:       w.PageRange(),
+		KeyVolume:          w.Biblio.Volume,
+		KeyIssue:           w.Biblio.Issue,
+		KeyPublicationName: src.DisplayName,
+		KeySourceType:      src.Type,
+		KeyType:            w.Type,
+		KeyPublisher:       src.HostOrgName,
+		KeyAuthors:         w.Authors(),
+		KeyCitedByCount:    fmt.Sprintf("%d", w.CitedByCount),
+		KeyAffiliation:     w.Affiliation(),
+	}
+}
+
+// Abstract rebuilds the abstract text from the inverted index OpenAlex
+// delivers in place of the plain text, which maps each word to the
+// positions it occupies.
+func (w work) Abstract() (lit.Abstract, bool) {
+	if len(w.InvertedIndex) == 0 {
+		return lit.Abstract{}, false
+	}
+	return lit.Abstract{
+		Text: invertedIndexText(w.InvertedIndex),
+	}, true
+}
+
+// invertedIndexText joins the words of index, which maps each word to the
+// positions it occupies, by increasing position.
+func invertedIndexText(index map[string][]int) string {
+	type position struct {
+		word string
+		at   int
+	}
+	words := []position{}
+	for w, positions := range index {
+		for _, at := range positions {
+			words = append(words, position{word: w, at: at})
+		}
+	}
+	sort.Slice(words, func(i, j int) bool {
+		return words[i].at < words[j].at
+	})
+
+	text := make([]string, len(words))
+	for i, v := range words {
+		text[i] = v.word
+	}
+	return strings.Join(text, " ")
+}
+
+func (c Client) ParsePublication(b lit.Blob) (lit.Publication, error) {
+	var w work
+	if err := json.Unmarshal([]byte(b), &w); err != nil {
+		return lit.Publication{}, err
+	}
+	coverDate, err := w.CoverDate()
+	if err != nil {
+		return lit.Publication{}, fmt.Errorf("work %s: %w", w.ID, err)
+	}
+
+	values := w.Values()
+	citedBy := w.CitedByCount
+	p := lit.Publication{
+		Title:     w.GetTitle(),
+		CoverDate: coverDate,
+		Creator:   w.Creator(),
+		Authors:   w.AuthorList(),
+		IDs: lit.Identifiers{
+			DOI:  values[KeyDOI],
+			PMID: strings.TrimPrefix(w.IDs.PMID, "https://pubmed.ncbi.nlm.nih.gov/"),
+		},
+		Venue:    values[KeyPublicationName],
+		Volume:   w.Biblio.Volume,
+		Issue:    w.Biblio.Issue,
+		Pages:    w.PageRange(),
+		Type:     w.DocumentType(),
+		Language: w.Language,
+		CitedBy:  &citedBy,
+		Values:   values,
+	}
+	for _, v := range w.Keywords {
+		p.SourceKeywords = append(p.SourceKeywords, v.DisplayName)
+	}
+	if abs, ok := w.Abstract(); ok {
+		p.Abstract = &abs
+	}
+	return p, nil
+}
+
+func workPath(p lit.Publication) (string, error) {
+	if id := p.Values[KeyID]; id != "" {
+		return "/works/" + url.PathEscape(id), nil
+	}
+	// Allows OpenAlex to provide abstracts for publications coming from
+	// other libraries.
+	if doi := p.DOI(); doi != "" {
+		return "/works/doi:" + doi, nil
+	}
+	return "", fmt.Errorf("publication %q has neither an OpenAlex id nor a DOI", p.Title)
+}
+
+func (c Client) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	if p.Abstract != nil {
+		return *p.Abstract, nil
+	}
+
+	path, err := workPath(p)
+	if err != nil {
+		return lit.Abstract{}, err
+	}
+	resp, err := c.httpClient.Do(c.newRequest(ctx, path, nil))
+	if err != nil {
+		return lit.Abstract{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return lit.Abstract{}, extractError(resp)
+	}
+
+	var w work
+	if err := json.NewDecoder(resp.Body).Decode(&w); err != nil {
+		return lit.Abstract{}, err
+	}
+	abs, ok := w.Abstract()
+	if !ok {
+		return lit.Abstract{}, fmt.Errorf("work %s has no abstract", w.ID)
+	}
+	return abs, nil
+}
+
+// HTTPClient returns the client used to talk to the API, see
+// lit.HTTPLibrary.
+func (c Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+func (c Client) GetName() string {
+	return "OpenAlex"
+}
+
+// NativeID returns the OpenAlex work ID of p, e.g. W2741809807.
+func (c Client) NativeID(p lit.Publication) string {
+	return p.Values[KeyID]
+}
+
+func (c Client) ToBibTeX(p lit.Publication) bibtex.Reference {
+	entry := p.BibTeXEntry()
+	venue := p.Values[KeyPublicationName]
+
+	switch {
+	case p.Values[KeySourceType] == "conference":
+		return bibtex.InProceedings{
+			Entry:     entry,
+			BookTitle: venue,
+			PageRange: p.Value(KeyPageRange),
+			Publisher: p.Value(KeyPublisher),
+			Address:   p.Value(KeyAffiliation),
+		}
+	case p.Values[KeyType] == "book-chapter":
+		return bibtex.InCollection{
+			Entry:     entry,
+			BookTitle: venue,
+			Publisher: p.Values[KeyPublisher],
+			PageRange: p.Value(KeyPageRange),
+			Address:   p.Value(KeyAffiliation),
+		}
+	case p.Values[KeyType] == "book":
+		return bibtex.Book{
+			Entry:     entry,
+			Publisher: p.Values[KeyPublisher],
+			Address:   p.Value(KeyAffiliation),
+		}
+	case p.Values[KeySourceType] == "journal":
+		return bibtex.Article{
+			Entry:     entry,
+			Journal:   venue,
+			Volume:    p.Value(KeyVolume),
+			Number:    p.Value(KeyIssue),
+			PageRange: p.Value(KeyPageRange),
+		}
+	default:
+		note := fmt.Sprintf("%q", p.Values)
+		return bibtex.Misc{
+			Entry: entry,
+			Note:  &note,
+		}
+	}
+}
+
+func (c Client) ReferenceLink(p lit.Publication) string {
+	if doi := p.DOI(); doi != "" {
+		return "https://doi.org/" + doi
+	}
+	return p.Values[KeyLinkAbstract]
+}
+
+// NewClient returns a client for the OpenAlex works API. The API does not
+// require authentication, but providing an email address in mailto grants
+// access to the faster "polite pool".
+func NewClient(mailto string) Client {
+	tr := &http.Transport{
+		MaxIdleConns:       10,
+		IdleConnTimeout:    15 * time.Second,
+		DisableCompression: false,
+	}
+
+	return Client{
+		mailto:     mailto,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Transport: tr},
+	}
+}
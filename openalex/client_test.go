@@ -0,0 +1,341 @@
+package openalex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/littest"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c := NewClient("reviewer@example.com")
+	c.endpoint = srv.URL
+	return c
+}
+
+func TestGetMaxLiterature(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have := r.URL.Query().Get("search"); have != "fpga" {
+			t.Errorf("search: have %q, want %q", have, "fpga")
+		}
+		if have := r.URL.Query().Get("mailto"); have != "reviewer@example.com" {
+			t.Errorf("mailto: have %q", have)
+		}
+		fmt.Fprint(w, worksPage)
+	})
+
+	have, err := c.GetMaxLiterature(context.Background(), lit.Request{Query: "fpga"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 1234; have != want {
+		t.Fatalf("max literature: have %d, want %d", have, want)
+	}
+}
+
+func TestGetLiteraturePaging(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if have := q.Get("page"); have != "3" {
+			t.Errorf("page: have %q, want %q", have, "3")
+		}
+		if have := q.Get("cursor"); have != "" {
+			t.Errorf("cursor should not be set, have %q", have)
+		}
+		fmt.Fprint(w, worksPage)
+	})
+
+	resp, err := c.GetLiterature(context.Background(), lit.Request{
+		Query:   "fpga",
+		Page:    2,
+		PerPage: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 2 {
+		t.Fatalf("blobs: have %d, want 2", resp.Len())
+	}
+}
+
+func TestGetLiteratureCursor(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if have := q.Get("page"); have != "" {
+			t.Errorf("page should not be set, have %q", have)
+		}
+		switch q.Get("cursor") {
+		case lit.CursorStart:
+			fmt.Fprint(w, worksPage)
+		case "IlsxNjA5MzcyODAwMDAwLCAnaHR0cHM6Ly9vcGVuYWxleC5vcmcvVzI0ODg0OTk3NjQnXSI=":
+			fmt.Fprint(w, `{"meta":{"count":1234,"next_cursor":null},"results":[]}`)
+		default:
+			t.Errorf("unexpected cursor %q", q.Get("cursor"))
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	req := lit.Request{Query: "fpga", PerPage: 2, Cursor: lit.CursorStart}
+	first, err := c.GetLiterature(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Len() != 2 || first.Next == "" {
+		t.Fatalf("first page: have %d blobs and next cursor %q", first.Len(), first.Next)
+	}
+
+	req.Cursor = first.Next
+	last, err := c.GetLiterature(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !last.IsEmpty() || last.Next != "" {
+		t.Fatalf("last page: have %d blobs and next cursor %q", last.Len(), last.Next)
+	}
+}
+
+func TestParsePublication(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, worksPage)
+	})
+	resp, err := c.GetLiterature(context.Background(), lit.Request{Query: "fpga", PerPage: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "FPGA-based accelerators of deep learning networks"; p.Title != want {
+		t.Fatalf("title: have %q, want %q", p.Title, want)
+	}
+	if want := "Griffin Lacey"; p.Creator != want {
+		t.Fatalf("creator: have %q, want %q", p.Creator, want)
+	}
+	if p.CoverDate.Year() != 2016 {
+		t.Fatalf("cover date: have %v", p.CoverDate)
+	}
+	if want := "10.48550/arxiv.1602.04283"; p.Values[KeyDOI] != want {
+		t.Fatalf("doi: have %q, want %q", p.Values[KeyDOI], want)
+	}
+	if p.IDs.DOI != "10.48550/arxiv.1602.04283" || p.Type != lit.TypeArticle || p.CitedBy == nil || *p.CitedBy != 187 {
+		t.Fatalf("unexpected metadata %+v, %s, %v", p.IDs, p.Type, p.CitedBy)
+	}
+	if want := (lit.Author{Name: "Graham W. Taylor", Affiliation: "University of Guelph"}); len(p.Authors) != 2 || p.Authors[1] != want {
+		t.Fatalf("authors: have %+v", p.Authors)
+	}
+	if p.Abstract == nil {
+		t.Fatalf("abstract was not rebuilt from the inverted index")
+	}
+	if want := "Recent breakthroughs in deep neural networks have led to FPGA accelerators"; p.Abstract.Text != want {
+		t.Fatalf("abstract: have %q, want %q", p.Abstract.Text, want)
+	}
+
+	ref := c.ToBibTeX(p)
+	if ref.EntryType() != bibtex.EntryTypeArticle {
+		t.Fatalf("entry type: have %q, want %q", ref.EntryType(), bibtex.EntryTypeArticle)
+	}
+	if want := "Griffin Lacey and Graham W. Taylor"; ref.Fields()["author"] != want {
+		t.Fatalf("author: have %q, want %q", ref.Fields()["author"], want)
+	}
+	if want := "12-19"; ref.Fields()["pages"] != want {
+		t.Fatalf("pages: have %q, want %q", ref.Fields()["pages"], want)
+	}
+
+	p, err = c.ParsePublication(resp.Blobs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref := c.ToBibTeX(p); ref.EntryType() != bibtex.EntryTypeInProceedings {
+		t.Fatalf("entry type: have %q, want %q", ref.EntryType(), bibtex.EntryTypeInProceedings)
+	}
+	if p.Type != lit.TypeConferencePaper {
+		t.Fatalf("type: have %q, want %q", p.Type, lit.TypeConferencePaper)
+	}
+	if p.Abstract != nil {
+		t.Fatalf("abstract: expected none, have %q", p.Abstract.Text)
+	}
+}
+
+func TestGetAbstractByDOI(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/works/doi:10.1145/3020078.3021740"; r.URL.Path != want {
+			t.Errorf("path: have %q, want %q", r.URL.Path, want)
+		}
+		fmt.Fprint(w, `{"id":"https://openalex.org/W1","abstract_inverted_index":{"world":[1],"hello":[0]}}`)
+	})
+
+	// Publications coming from other libraries, known only by their
+	// DOI, stored before they had typed identifiers or not.
+	title := "Can FPGAs beat GPUs in accelerating next-generation deep neural networks?"
+	for _, p := range []lit.Publication{
+		{Title: title, IDs: lit.Identifiers{DOI: "10.1145/3020078.3021740"}},
+		{Title: title, Values: map[string]string{"doi": "10.1145/3020078.3021740"}},
+	} {
+		abs, err := c.GetAbstract(context.Background(), p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "hello world"; abs.Text != want {
+			t.Fatalf("abstract: have %q, want %q", abs.Text, want)
+		}
+	}
+}
+
+func TestFacets(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have := r.URL.Query().Get("group_by"); have != "publication_year" {
+			t.Errorf("group_by: have %q", have)
+		}
+		fmt.Fprint(w, `{"meta":{"count":1234,"groups_count":3},"results":[],"group_by":[{"key":"2021","key_display_name":"2021","count":700},{"key":"2019","key_display_name":"2019","count":34},{"key":"2020","key_display_name":"2020","count":500}]}`)
+	})
+
+	facets, err := c.Facets(context.Background(), lit.Request{Query: "fpga"}, lit.FacetYear)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[{2019 34} {2020 500} {2021 700}]"; fmt.Sprint(facets) != want {
+		t.Fatalf("facets: have %v, want %s", facets, want)
+	}
+	if _, err := c.Facets(context.Background(), lit.Request{Query: "fpga"}, "language"); !errors.Is(err, lit.ErrUnsupported) {
+		t.Fatalf("have %v, want %v", err, lit.ErrUnsupported)
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":"Invalid query parameters error.","message":"Maximum results size of 10,000 records is exceeded."}`)
+	})
+
+	_, err := c.GetLiterature(context.Background(), lit.Request{Query: "fpga", Page: 1000, PerPage: 25})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "403 Forbidden: Maximum results size of 10,000 records is exceeded."; err.Error() != want {
+		t.Fatalf("error: have %q, want %q", err, want)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	var page struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(worksPage), &page); err != nil {
+		t.Fatal(err)
+	}
+	works := littest.Records(42, page.Results, func(i int, w map[string]interface{}) {
+		w["id"] = fmt.Sprintf("https://openalex.org/W%d", i)
+		w["doi"] = fmt.Sprintf("https://doi.org/10.1145/3020078.%d", i)
+		w["display_name"] = fmt.Sprintf("%s, part %d", w["display_name"], i)
+	})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		n, _ := strconv.Atoi(q.Get("page"))
+		perPage, _ := strconv.Atoi(q.Get("per-page"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"meta":    map[string]interface{}{"count": len(works)},
+			"results": littest.Page(works, (n-1)*perPage, perPage),
+		})
+	})
+	littest.RunConformance(t, c, littest.Fixture{Query: "fpga", PerPage: 10, Max: 42})
+}
+
+// Trimmed down response recorded from
+// https://api.openalex.org/works?search=fpga&per-page=2&cursor=*
+const worksPage = `{
+  "meta": {
+    "count": 1234,
+    "db_response_time_ms": 48,
+    "page": null,
+    "per_page": 2,
+    "next_cursor": "IlsxNjA5MzcyODAwMDAwLCAnaHR0cHM6Ly9vcGVuYWxleC5vcmcvVzI0ODg0OTk3NjQnXSI="
+  },
+  "results": [
+    {
+      "id": "https://openalex.org/W2279098554",
+      "doi": "https://doi.org/10.48550/arxiv.1602.04283",
+      "title": "FPGA-based accelerators of deep learning networks",
+      "display_name": "FPGA-based accelerators of deep learning networks",
+      "publication_year": 2016,
+      "publication_date": "2016-02-13",
+      "type": "article",
+      "cited_by_count": 187,
+      "authorships": [
+        {
+          "author_position": "first",
+          "author": {"id": "https://openalex.org/A1", "display_name": "Griffin Lacey", "orcid": null},
+          "institutions": [{"display_name": "University of Guelph", "country_code": "CA"}]
+        },
+        {
+          "author_position": "last",
+          "author": {"id": "https://openalex.org/A2", "display_name": "Graham W. Taylor", "orcid": null},
+          "institutions": [{"display_name": "University of Guelph", "country_code": "CA"}]
+        }
+      ],
+      "primary_location": {
+        "landing_page_url": "https://arxiv.org/abs/1602.04283",
+        "source": {
+          "display_name": "Journal of Hardware Acceleration",
+          "issn_l": "1234-5678",
+          "type": "journal",
+          "host_organization_name": "Cornell University"
+        }
+      },
+      "biblio": {"volume": "4", "issue": "2", "first_page": "12", "last_page": "19"},
+      "abstract_inverted_index": {
+        "Recent": [0],
+        "breakthroughs": [1],
+        "in": [2],
+        "deep": [3],
+        "neural": [4],
+        "networks": [5],
+        "have": [6],
+        "led": [7],
+        "to": [8],
+        "FPGA": [9],
+        "accelerators": [10]
+      }
+    },
+    {
+      "id": "https://openalex.org/W2488499764",
+      "doi": null,
+      "title": "Going Deeper with Embedded FPGA Platform for Convolutional Neural Network",
+      "display_name": "Going Deeper with Embedded FPGA Platform for Convolutional Neural Network",
+      "publication_year": 2016,
+      "publication_date": "2016-02-21",
+      "type": "article",
+      "cited_by_count": 1012,
+      "authorships": [
+        {
+          "author_position": "first",
+          "author": {"id": "https://openalex.org/A3", "display_name": "Jiantao Qiu"},
+          "institutions": []
+        }
+      ],
+      "primary_location": {
+        "landing_page_url": null,
+        "source": {
+          "display_name": "Field Programmable Gate Arrays",
+          "issn_l": null,
+          "type": "conference",
+          "host_organization_name": null
+        }
+      },
+      "biblio": {"volume": null, "issue": null, "first_page": "26", "last_page": "35"},
+      "abstract_inverted_index": null
+    }
+  ]
+}`
@@ -0,0 +1,147 @@
+package lit
+
+import (
+	"strings"
+
+	"github.com/jecoz/lit/bibtex"
+)
+
+// Author is one of the authors of a publication, in the order the
+// publication lists them.
+type Author struct {
+	// Name is in "Surname, Given Name" form when the library tells the
+	// two apart, as the library reports it otherwise.
+	Name        string `json:"name"`
+	ORCID       string `json:"orcid,omitempty"`
+	Affiliation string `json:"affiliation,omitempty"`
+}
+
+// Identifiers are the identifiers a publication is known by, empty when
+// unknown to the library it comes from.
+type Identifiers struct {
+	DOI   string `json:"doi,omitempty"`   // e.g. 10.1145/3020078.3021740
+	EID   string `json:"eid,omitempty"`   // Scopus, e.g. 2-s2.0-85016079383
+	PMID  string `json:"pmid,omitempty"`  // PubMed, e.g. 29950123
+	ArXiv string `json:"arxiv,omitempty"` // e.g. 1706.03762
+	ISBN  string `json:"isbn,omitempty"`
+}
+
+// DocumentType is the kind of a publication, as far as systematic
+// reviews are concerned.
+type DocumentType string
+
+const (
+	TypeArticle         DocumentType = "article"
+	TypeReview          DocumentType = "review"
+	TypeConferencePaper DocumentType = "conference_paper"
+	TypeBook            DocumentType = "book"
+	TypeBookChapter     DocumentType = "book_chapter"
+	TypePreprint        DocumentType = "preprint"
+	TypeThesis          DocumentType = "thesis"
+	TypeReport          DocumentType = "report"
+	TypeOther           DocumentType = "other"
+)
+
+// AuthorNames returns the names of the authors of p joined by "and", as
+// BibTeX wants them, or its creator when the authors are unknown.
+func (p Publication) AuthorNames() string {
+	if len(p.Authors) == 0 {
+		return p.Creator
+	}
+	names := make([]string, len(p.Authors))
+	for i, v := range p.Authors {
+		names[i] = v.Name
+	}
+	return strings.Join(names, " and ")
+}
+
+// DOI returns the DOI of p, looking it up among its values when the
+// library did not set its identifiers, as with publications stored
+// before they had any.
+func (p Publication) DOI() string {
+	if p.IDs.DOI != "" {
+		return p.IDs.DOI
+	}
+	return p.Values[KeyDOI]
+}
+
+// Value returns the value p has for key, nil when it is empty, as the
+// optional fields of BibTeX references want it.
+func (p Publication) Value(key string) *string {
+	v := p.Values[key]
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// BibTeXEntry returns the fields every BibTeX reference to p shares,
+// completed by BibTeXFormatter implementations with the ones of its
+// type. Authors are the ones under KeyAuthors when the library lists them
+// all there, e.g. Scopus with the COMPLETE view, AuthorNames otherwise.
+func (p Publication) BibTeXEntry() bibtex.Entry {
+	author := p.Values[KeyAuthors]
+	if author == "" {
+		author = p.AuthorNames()
+	}
+	e := bibtex.Entry{
+		Title:  p.Title,
+		Author: author,
+		Year:   p.CoverDate.Year(),
+		Issn:   p.Value(KeyIssn),
+		Url:    p.Value(KeyLinkAbstract),
+	}
+	if doi := p.DOI(); doi != "" {
+		e.DOI = &doi
+	}
+	if abs := p.Abstract; abs != nil {
+		e.Abstract = &abs.Text
+	}
+	if k := p.Keywords; k != nil {
+		text := k.Text()
+		e.Keywords = &text
+	}
+	if rev := p.Review; rev != nil && !rev.IsAccepted {
+		e.RejectReason = &rev.RejectReason
+	}
+	return e
+}
+
+// Complete fills the metadata p is missing with the one of o, which is
+// expected to describe the same work, e.g. a duplicate coming from
+// another library. What p has is left untouched, authors included: they
+// are taken from o only when p has none.
+func (p *Publication) Complete(o Publication) {
+	if len(p.Authors) == 0 {
+		p.Authors = o.Authors
+	}
+	fields := []struct {
+		dst *string
+		src string
+	}{
+		{&p.IDs.DOI, o.IDs.DOI},
+		{&p.IDs.EID, o.IDs.EID},
+		{&p.IDs.PMID, o.IDs.PMID},
+		{&p.IDs.ArXiv, o.IDs.ArXiv},
+		{&p.IDs.ISBN, o.IDs.ISBN},
+		{&p.Venue, o.Venue},
+		{&p.Volume, o.Volume},
+		{&p.Issue, o.Issue},
+		{&p.Pages, o.Pages},
+		{&p.Language, o.Language},
+	}
+	for _, v := range fields {
+		if *v.dst == "" {
+			*v.dst = v.src
+		}
+	}
+	if p.Type == "" {
+		p.Type = o.Type
+	}
+	if p.CitedBy == nil {
+		p.CitedBy = o.CitedBy
+	}
+	if len(p.SourceKeywords) == 0 {
+		p.SourceKeywords = o.SourceKeywords
+	}
+}
@@ -0,0 +1,666 @@
+package pubmed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/xmlfmt"
+)
+
+const endpoint = "https://eutils.ncbi.nlm.nih.gov/entrez/eutils"
+
+const (
+	KeyPMID             = "pmid"
+	KeyPMC              = "pmc"
+	KeyDOI              = lit.KeyDOI
+	KeyIssn             = lit.KeyIssn
+	KeyPublicationName  = lit.KeyPublicationName
+	KeyVolume           = lit.KeyVolume
+	KeyIssue            = lit.KeyIssue
+	KeyPageRange        = lit.KeyPageRange
+	KeyAuthors          = lit.KeyAuthors
+	KeyAffiliation      = "affiliation"
+	KeyLanguage         = "language"
+	KeyPublicationTypes = "publication_types"
+	KeyMeSH             = "mesh_headings"
+	KeySourceKeywords   = "source_keywords"
+	KeyLinkAbstract     = lit.KeyLinkAbstract
+)
+
+// History server sessions are dropped by NCBI after a period of
+// inactivity, after which a new search has to be issued.
+const historyTTL = 30 * time.Minute
+
+type history struct {
+	webEnv   string
+	queryKey string
+	count    int
+	created  time.Time
+}
+
+type historyCache struct {
+	sync.Mutex
+	entries map[string]history
+}
+
+func (c *historyCache) get(q string) (history, bool) {
+	c.Lock()
+	defer c.Unlock()
+	h, ok := c.entries[q]
+	if !ok || time.Since(h.created) > historyTTL {
+		return history{}, false
+	}
+	return h, true
+}
+
+func (c *historyCache) put(q string, h history) {
+	c.Lock()
+	defer c.Unlock()
+	c.entries[q] = h
+}
+
+type Client struct {
+	apiKey     string
+	endpoint   string
+	httpClient *http.Client
+	history    *historyCache
+}
+
+func (c Client) DefaultPerPage() int {
+	return 100
+}
+
+func (c Client) newRequest(ctx context.Context, tool string, q url.Values) *http.Request {
+	u, err := url.Parse(c.endpoint + "/" + tool)
+	if err != nil {
+		panic(err)
+	}
+	q.Set("db", "pubmed")
+	if c.apiKey != "" {
+		q.Set("api_key", c.apiKey)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		panic(err)
+	}
+	return req
+}
+
+func (c Client) do(req *http.Request, handle func(*http.Response) error) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return lit.NewStatusError(resp, "")
+	}
+	return handle(resp)
+}
+
+// search posts the query to the history server, so that results can be
+// later fetched in pages referencing the WebEnv and query_key obtained.
+func (c Client) search(ctx context.Context, query string) (history, error) {
+	// Sessions are reused without asking the server, which would have
+	// noticed ctx is done.
+	if err := ctx.Err(); err != nil {
+		return history{}, err
+	}
+	if h, ok := c.history.get(query); ok {
+		return h, nil
+	}
+
+	req := c.newRequest(ctx, "esearch.fcgi", url.Values{
+		"term":       {query},
+		"usehistory": {"y"},
+		"retmax":     {"0"},
+		"retmode":    {"json"},
+	})
+	var p struct {
+		Result struct {
+			Count    string `json:"count"`
+			QueryKey string `json:"querykey"`
+			WebEnv   string `json:"webenv"`
+			Error    string `json:"ERROR"`
+		} `json:"esearchresult"`
+		Error string `json:"error"`
+	}
+	if err := c.do(req, func(resp *http.Response) error {
+		return json.NewDecoder(resp.Body).Decode(&p)
+	}); err != nil {
+		return history{}, err
+	}
+	if p.Error != "" {
+		return history{}, fmt.Errorf("esearch: %s", p.Error)
+	}
+	if p.Result.Error != "" {
+		return history{}, fmt.Errorf("esearch: %s", p.Result.Error)
+	}
+
+	n, err := strconv.Atoi(p.Result.Count)
+	if err != nil {
+		return history{}, fmt.Errorf("esearch: unexpected count field: %w", err)
+	}
+	h := history{
+		webEnv:   p.Result.WebEnv,
+		queryKey: p.Result.QueryKey,
+		count:    n,
+		created:  time.Now(),
+	}
+	c.history.put(query, h)
+	return h, nil
+}
+
+type rawItem struct {
+	XMLName xml.Name
+	Inner   []byte `xml:",innerxml"`
+}
+
+func (i rawItem) Blob() lit.Blob {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%s>", i.XMLName.Local)
+	buf.Write(i.Inner)
+	fmt.Fprintf(&buf, "</%s>", i.XMLName.Local)
+	return lit.Blob(buf.Bytes())
+}
+
+// fetch returns the MEDLINE records, one blob per PubmedArticle or
+// PubmedBookArticle element.
+func (c Client) fetch(ctx context.Context, q url.Values) ([]lit.Blob, error) {
+	q.Set("retmode", "xml")
+	req := c.newRequest(ctx, "efetch.fcgi", q)
+
+	var set struct {
+		Items []rawItem `xml:",any"`
+	}
+	if err := c.do(req, func(resp *http.Response) error {
+		return xml.NewDecoder(resp.Body).Decode(&set)
+	}); err != nil {
+		return nil, err
+	}
+
+	blobs := make([]lit.Blob, 0, len(set.Items))
+	for _, v := range set.Items {
+		switch v.XMLName.Local {
+		case "PubmedArticle", "PubmedBookArticle":
+			blobs = append(blobs, v.Blob())
+		}
+	}
+	return blobs, nil
+}
+
+func (c Client) GetLiterature(ctx context.Context, req lit.Request) (lit.Response, error) {
+	h, err := c.search(ctx, req.Query)
+	if err != nil {
+		return lit.Response{}, err
+	}
+	blobs, err := c.fetch(ctx, url.Values{
+		"WebEnv":    {h.webEnv},
+		"query_key": {h.queryKey},
+		"retstart":  {fmt.Sprintf("%d", req.PerPage*req.Page)},
+		"retmax":    {fmt.Sprintf("%d", req.PerPage)},
+	})
+	if err != nil {
+		return lit.Response{}, err
+	}
+	return lit.Response{
+		Req:   req,
+		Blobs: blobs,
+	}, nil
+}
+
+func (c Client) GetMaxLiterature(ctx context.Context, req lit.Request) (int, error) {
+	h, err := c.search(ctx, req.Query)
+	if err != nil {
+		return 0, err
+	}
+	return h.count, nil
+}
+
+func (c Client) GetRateLimit() time.Duration {
+	// https://www.ncbi.nlm.nih.gov/books/NBK25497/
+	if c.apiKey != "" {
+		return time.Millisecond * 1000 / time.Duration(10)
+	}
+	return time.Millisecond * 1000 / time.Duration(3)
+}
+
+func (c Client) ConcurrencyLimit() int {
+	if c.apiKey != "" {
+		return 10
+	}
+	return 3
+}
+
+func (c Client) PrettyPrint(b lit.Blob, dst *bytes.Buffer) error {
+	return xmlfmt.Indent(dst, []byte(b), "", "\t")
+}
+
+// text collects the character data of an element and of all its
+// children, as titles and abstracts may contain markup such as <i>.
+type text string
+
+func (t *text) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var buf strings.Builder
+	for depth := 1; depth > 0; {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			buf.Write(tok)
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	*t = text(strings.Join(strings.Fields(buf.String()), " "))
+	return nil
+}
+
+type abstractText struct {
+	Label string
+	Text  text
+}
+
+func (a *abstractText) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, v := range start.Attr {
+		if v.Name.Local == "Label" {
+			a.Label = v.Value
+		}
+	}
+	return a.Text.UnmarshalXML(d, start)
+}
+
+type abstract struct {
+	Texts []abstractText `xml:"AbstractText"`
+}
+
+// Text joins the sections of structured abstracts, prefixing each one
+// with its label.
+func (a abstract) Text() string {
+	sections := make([]string, 0, len(a.Texts))
+	for _, v := range a.Texts {
+		if v.Label != "" {
+			sections = append(sections, fmt.Sprintf("%s: %s", v.Label, v.Text))
+			continue
+		}
+		sections = append(sections, string(v.Text))
+	}
+	return strings.Join(sections, " ")
+}
+
+type authorID struct {
+	Source string `xml:"Source,attr"`
+	ID     string `xml:",chardata"`
+}
+
+type author struct {
+	LastName       string     `xml:"LastName"`
+	ForeName       string     `xml:"ForeName"`
+	CollectiveName string     `xml:"CollectiveName"`
+	Affiliations   []string   `xml:"AffiliationInfo>Affiliation"`
+	Identifiers    []authorID `xml:"Identifier"`
+}
+
+// ORCID returns the ORCID iD of the author, which PubMed stores either
+// bare or as a URL.
+func (a author) ORCID() string {
+	for _, v := range a.Identifiers {
+		if v.Source == "ORCID" {
+			id := strings.TrimSpace(v.ID)
+			return id[strings.LastIndex(id, "/")+1:]
+		}
+	}
+	return ""
+}
+
+func (a author) Name() string {
+	if a.CollectiveName != "" {
+		return a.CollectiveName
+	}
+	if a.ForeName == "" {
+		return a.LastName
+	}
+	return a.LastName + ", " + a.ForeName
+}
+
+type pubDate struct {
+	Year        string `xml:"Year"`
+	Month       string `xml:"Month"`
+	Day         string `xml:"Day"`
+	MedlineDate string `xml:"MedlineDate"`
+}
+
+var yearRgx = regexp.MustCompile(`\d{4}`)
+
+func (d pubDate) Time() (time.Time, error) {
+	if d.Year == "" {
+		y := yearRgx.FindString(d.MedlineDate)
+		if y == "" {
+			return time.Time{}, fmt.Errorf("parse publication date: no year in %q", d.MedlineDate)
+		}
+		d = pubDate{Year: y}
+	}
+
+	year, err := strconv.Atoi(d.Year)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse publication date: %w", err)
+	}
+	month := time.January
+	if d.Month != "" {
+		if n, err := strconv.Atoi(d.Month); err == nil {
+			month = time.Month(n)
+		} else if t, err := time.Parse("Jan", d.Month); err == nil {
+			month = t.Month()
+		}
+	}
+	day := 1
+	if n, err := strconv.Atoi(d.Day); err == nil {
+		day = n
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+}
+
+type articleID struct {
+	Type string `xml:"IdType,attr"`
+	ID   string `xml:",chardata"`
+}
+
+type meshHeading struct {
+	Descriptor string   `xml:"DescriptorName"`
+	Qualifiers []string `xml:"QualifierName"`
+}
+
+func (m meshHeading) String() string {
+	if len(m.Qualifiers) == 0 {
+		return m.Descriptor
+	}
+	return m.Descriptor + "/" + strings.Join(m.Qualifiers, "/")
+}
+
+// record is the subset of a MEDLINE citation lit cares about. Book
+// articles share most of the fields, rooted in BookDocument in place of
+// MedlineCitation.
+type record struct {
+	XMLName xml.Name
+
+	PMID         string        `xml:"MedlineCitation>PMID"`
+	Title        text          `xml:"MedlineCitation>Article>ArticleTitle"`
+	Abstract     abstract      `xml:"MedlineCitation>Article>Abstract"`
+	Authors      []author      `xml:"MedlineCitation>Article>AuthorList>Author"`
+	Journal      string        `xml:"MedlineCitation>Article>Journal>Title"`
+	Issn         string        `xml:"MedlineCitation>Article>Journal>ISSN"`
+	Volume       string        `xml:"MedlineCitation>Article>Journal>JournalIssue>Volume"`
+	Issue        string        `xml:"MedlineCitation>Article>Journal>JournalIssue>Issue"`
+	PubDate      pubDate       `xml:"MedlineCitation>Article>Journal>JournalIssue>PubDate"`
+	Pages        string        `xml:"MedlineCitation>Article>Pagination>MedlinePgn"`
+	Languages    []string      `xml:"MedlineCitation>Article>Language"`
+	PubTypes     []string      `xml:"MedlineCitation>Article>PublicationTypeList>PublicationType"`
+	MeshHeadings []meshHeading `xml:"MedlineCitation>MeshHeadingList>MeshHeading"`
+	Keywords     []string      `xml:"MedlineCitation>KeywordList>Keyword"`
+	ArticleIDs   []articleID   `xml:"PubmedData>ArticleIdList>ArticleId"`
+
+	BookPMID      string   `xml:"BookDocument>PMID"`
+	BookTitle     text     `xml:"BookDocument>ArticleTitle"`
+	BookName      text     `xml:"BookDocument>Book>BookTitle"`
+	BookAbstract  abstract `xml:"BookDocument>Abstract"`
+	BookAuthors   []author `xml:"BookDocument>AuthorList>Author"`
+	BookPubDate   pubDate  `xml:"BookDocument>Book>PubDate"`
+	BookLanguages []string `xml:"BookDocument>Language"`
+}
+
+func (r record) IsBook() bool {
+	return r.XMLName.Local == "PubmedBookArticle"
+}
+
+// normalize moves book fields in place of the MEDLINE citation ones.
+func (r record) normalize() record {
+	if !r.IsBook() {
+		return r
+	}
+	r.PMID = r.BookPMID
+	r.Title = r.BookTitle
+	if r.Title == "" {
+		r.Title = r.BookName
+	}
+	r.Journal = string(r.BookName)
+	r.Abstract = r.BookAbstract
+	r.Authors = r.BookAuthors
+	r.PubDate = r.BookPubDate
+	r.Languages = r.BookLanguages
+	return r
+}
+
+func (r record) articleID(t string) string {
+	for _, v := range r.ArticleIDs {
+		if v.Type == t {
+			return v.ID
+		}
+	}
+	return ""
+}
+
+func (r record) AuthorList() []lit.Author {
+	if len(r.Authors) == 0 {
+		return nil
+	}
+	authors := make([]lit.Author, len(r.Authors))
+	for i, v := range r.Authors {
+		authors[i] = lit.Author{
+			Name:        v.Name(),
+			ORCID:       v.ORCID(),
+			Affiliation: strings.Join(v.Affiliations, "; "),
+		}
+	}
+	return authors
+}
+
+// DocumentType maps the publication types of the record onto the types
+// of lit, reviews and preprints taking precedence over journal articles.
+func (r record) DocumentType() lit.DocumentType {
+	if r.IsBook() {
+		if r.BookTitle != "" && r.BookTitle != r.BookName {
+			return lit.TypeBookChapter
+		}
+		return lit.TypeBook
+	}
+	types := make(map[string]bool, len(r.PubTypes))
+	for _, v := range r.PubTypes {
+		types[v] = true
+	}
+	switch {
+	case types["Preprint"]:
+		return lit.TypePreprint
+	case types["Review"], types["Systematic Review"], types["Meta-Analysis"]:
+		return lit.TypeReview
+	case types["Journal Article"]:
+		return lit.TypeArticle
+	case types["Congress"]:
+		return lit.TypeConferencePaper
+	case len(types) == 0:
+		return ""
+	default:
+		return lit.TypeOther
+	}
+}
+
+func (r record) Values() map[string]string {
+	authors := make([]string, len(r.Authors))
+	affiliations := []string{}
+	for i, v := range r.Authors {
+		authors[i] = v.Name()
+		affiliations = append(affiliations, v.Affiliations...)
+	}
+	mesh := make([]string, len(r.MeshHeadings))
+	for i, v := range r.MeshHeadings {
+		mesh[i] = v.String()
+	}
+
+	return map[string]string{
+		KeyPMID:             r.PMID,
+		KeyPMC:              r.articleID("pmc"),
+		KeyDOI:              r.articleID("doi"),
+		KeyIssn:             r.Issn,
+		KeyPublicationName:  r.Journal,
+		KeyVolume:           r.Volume,
+		KeyIssue:            r.Issue,
+		KeyPageRange:        r.Pages,
+		KeyAuthors:          strings.Join(authors, " and "),
+		KeyAffiliation:      strings.Join(affiliations, "; "),
+		KeyLanguage:         strings.Join(r.Languages, ", "),
+		KeyPublicationTypes: strings.Join(r.PubTypes, ", "),
+		KeyMeSH:             strings.Join(mesh, "; "),
+		KeySourceKeywords:   strings.Join(r.Keywords, ", "),
+		KeyLinkAbstract:     "https://pubmed.ncbi.nlm.nih.gov/" + r.PMID + "/",
+	}
+}
+
+func parseRecord(b lit.Blob) (record, error) {
+	var r record
+	if err := xml.Unmarshal([]byte(b), &r); err != nil {
+		return record{}, err
+	}
+	return r.normalize(), nil
+}
+
+func (c Client) ParsePublication(b lit.Blob) (lit.Publication, error) {
+	r, err := parseRecord(b)
+	if err != nil {
+		return lit.Publication{}, err
+	}
+	coverDate, err := r.PubDate.Time()
+	if err != nil {
+		return lit.Publication{}, fmt.Errorf("pmid %s: %w", r.PMID, err)
+	}
+
+	var creator string
+	if len(r.Authors) > 0 {
+		creator = r.Authors[0].Name()
+	}
+	p := lit.Publication{
+		Title:     string(r.Title),
+		CoverDate: coverDate,
+		Creator:   creator,
+		Authors:   r.AuthorList(),
+		IDs: lit.Identifiers{
+			DOI:  r.articleID("doi"),
+			PMID: r.PMID,
+		},
+		Venue:          r.Journal,
+		Volume:         r.Volume,
+		Issue:          r.Issue,
+		Pages:          r.Pages,
+		Type:           r.DocumentType(),
+		SourceKeywords: r.Keywords,
+		Values:         r.Values(),
+	}
+	if len(r.Languages) > 0 {
+		p.Language = r.Languages[0]
+	}
+	if text := r.Abstract.Text(); text != "" {
+		p.Abstract = &lit.Abstract{Text: text}
+	}
+	return p, nil
+}
+
+func (c Client) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	if p.Abstract != nil {
+		return *p.Abstract, nil
+	}
+
+	pmid := p.Values[KeyPMID]
+	if pmid == "" {
+		return lit.Abstract{}, fmt.Errorf("publication %q has no PMID", p.Title)
+	}
+	blobs, err := c.fetch(ctx, url.Values{"id": {pmid}})
+	if err != nil {
+		return lit.Abstract{}, err
+	}
+	if len(blobs) == 0 {
+		return lit.Abstract{}, fmt.Errorf("pmid %s not found", pmid)
+	}
+	r, err := parseRecord(blobs[0])
+	if err != nil {
+		return lit.Abstract{}, err
+	}
+	text := r.Abstract.Text()
+	if text == "" {
+		return lit.Abstract{}, fmt.Errorf("pmid %s has no abstract", pmid)
+	}
+	return lit.Abstract{
+		Text: text,
+	}, nil
+}
+
+// HTTPClient returns the client used to talk to the API, see
+// lit.HTTPLibrary.
+func (c Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+func (c Client) GetName() string {
+	return "PubMed"
+}
+
+// NativeID returns the PMID of p.
+func (c Client) NativeID(p lit.Publication) string {
+	return p.Values[KeyPMID]
+}
+
+func (c Client) ToBibTeX(p lit.Publication) bibtex.Reference {
+	if p.Values[KeyIssn] == "" {
+		note := fmt.Sprintf("PMID: %s", p.Values[KeyPMID])
+		return bibtex.Misc{
+			Entry: p.BibTeXEntry(),
+			Note:  &note,
+		}
+	}
+	return bibtex.Article{
+		Entry:     p.BibTeXEntry(),
+		Journal:   p.Values[KeyPublicationName],
+		Volume:    p.Value(KeyVolume),
+		Number:    p.Value(KeyIssue),
+		PageRange: p.Value(KeyPageRange),
+	}
+}
+
+func (c Client) ReferenceLink(p lit.Publication) string {
+	return p.Values[KeyLinkAbstract]
+}
+
+// NewClient returns a client for NCBI's E-utilities. The apiKey is
+// optional, when provided the rate limit is raised from 3 to 10 requests
+// per second.
+func NewClient(apiKey string) Client {
+	tr := &http.Transport{
+		MaxIdleConns:       10,
+		IdleConnTimeout:    15 * time.Second,
+		DisableCompression: false,
+	}
+
+	return Client{
+		apiKey:     apiKey,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Transport: tr},
+		history: &historyCache{
+			entries: make(map[string]history),
+		},
+	}
+}
@@ -0,0 +1,336 @@
+package pubmed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/littest"
+)
+
+type server struct {
+	t        *testing.T
+	searches int
+	fetches  int
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if have := q.Get("db"); have != "pubmed" {
+		s.t.Errorf("db: have %q, want pubmed", have)
+	}
+	switch r.URL.Path {
+	case "/esearch.fcgi":
+		s.searches++
+		if have := q.Get("usehistory"); have != "y" {
+			s.t.Errorf("usehistory: have %q, want y", have)
+		}
+		fmt.Fprint(w, esearchResult)
+	case "/efetch.fcgi":
+		s.fetches++
+		if id := q.Get("id"); id != "" {
+			if id != "31452104" {
+				s.t.Errorf("id: have %q, want 31452104", id)
+			}
+		} else {
+			if have := q.Get("WebEnv"); have != "MCID_61dc6f7e5b1bd2420f1a4a12" {
+				s.t.Errorf("WebEnv: have %q", have)
+			}
+			if have := q.Get("query_key"); have != "1" {
+				s.t.Errorf("query_key: have %q", have)
+			}
+			if have := q.Get("retstart"); have != "40" {
+				s.t.Errorf("retstart: have %q, want 40", have)
+			}
+		}
+		fmt.Fprint(w, efetchResult)
+	default:
+		s.t.Errorf("unexpected path %q", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T) (Client, *server) {
+	s := &server{t: t}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	c := NewClient("")
+	c.endpoint = srv.URL
+	return c, s
+}
+
+func TestHistoryPaging(t *testing.T) {
+	c, s := newTestClient(t)
+	ctx := context.Background()
+	req := lit.Request{Query: "fpga[tiab] AND neural network[tiab]", Page: 2, PerPage: 20}
+
+	max, err := c.GetMaxLiterature(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if max != 57 {
+		t.Fatalf("max literature: have %d, want 57", max)
+	}
+
+	resp, err := c.GetLiterature(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 2 {
+		t.Fatalf("blobs: have %d, want 2", resp.Len())
+	}
+	if s.searches != 1 {
+		t.Fatalf("the history server session should have been reused, have %d searches", s.searches)
+	}
+}
+
+func TestParsePublication(t *testing.T) {
+	c, _ := newTestClient(t)
+	resp, err := c.GetLiterature(context.Background(), lit.Request{Query: "fpga", Page: 2, PerPage: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "An FPGA-based accelerator for deep neural networks in clinical imaging."; p.Title != want {
+		t.Fatalf("title: have %q, want %q", p.Title, want)
+	}
+	if want := "Smith, Jane A"; p.Creator != want {
+		t.Fatalf("creator: have %q, want %q", p.Creator, want)
+	}
+	if p.CoverDate.Year() != 2019 || p.CoverDate.Month() != 8 {
+		t.Fatalf("cover date: have %v", p.CoverDate)
+	}
+	values := map[string]string{
+		KeyPMID:            "31452104",
+		KeyDOI:             "10.1109/JBHI.2019.2936211",
+		KeyPMC:             "PMC6789012",
+		KeyPublicationName: "IEEE journal of biomedical and health informatics",
+		KeyVolume:          "23",
+		KeyIssue:           "5",
+		KeyPageRange:       "1800-1810",
+		KeyAuthors:         "Smith, Jane A and Medical Imaging Consortium",
+		KeyMeSH:            "Humans; Neural Networks, Computer/instrumentation",
+		KeySourceKeywords:  "FPGA, hardware acceleration",
+	}
+	for k, want := range values {
+		if have := p.Values[k]; have != want {
+			t.Errorf("%s: have %q, want %q", k, have, want)
+		}
+	}
+
+	if p.Abstract == nil {
+		t.Fatal("structured abstract not found")
+	}
+	if want := "BACKGROUND: Deep networks are slow on embedded devices. METHODS: We designed an accelerator."; p.Abstract.Text != want {
+		t.Fatalf("abstract: have %q, want %q", p.Abstract.Text, want)
+	}
+	if ref := c.ToBibTeX(p); ref.EntryType() != bibtex.EntryTypeArticle {
+		t.Fatalf("entry type: have %q, want %q", ref.EntryType(), bibtex.EntryTypeArticle)
+	}
+	if want := (lit.Author{Name: "Smith, Jane A", ORCID: "0000-0002-1825-0097", Affiliation: "Department of Radiology, Example University."}); len(p.Authors) != 2 || p.Authors[0] != want {
+		t.Errorf("authors: have %+v", p.Authors)
+	}
+	if p.IDs != (lit.Identifiers{DOI: "10.1109/JBHI.2019.2936211", PMID: "31452104"}) || p.Type != lit.TypeArticle || p.Language != "eng" || p.Venue != values[KeyPublicationName] {
+		t.Errorf("unexpected metadata %+v, %s, %s, %s", p.IDs, p.Type, p.Language, p.Venue)
+	}
+
+	p, err = c.ParsePublication(resp.Blobs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Hardware for neural networks"; p.Title != want {
+		t.Fatalf("book title: have %q, want %q", p.Title, want)
+	}
+	if p.CoverDate.Year() != 2020 {
+		t.Fatalf("book cover date: have %v", p.CoverDate)
+	}
+	if p.Type != lit.TypeBook {
+		t.Fatalf("book type: have %q, want %q", p.Type, lit.TypeBook)
+	}
+}
+
+func TestGetAbstractByPMID(t *testing.T) {
+	c, s := newTestClient(t)
+	abs, err := c.GetAbstract(context.Background(), lit.Publication{
+		Values: map[string]string{KeyPMID: "31452104"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(abs.Text, "BACKGROUND:") {
+		t.Fatalf("abstract: have %q", abs.Text)
+	}
+	if s.searches != 0 {
+		t.Fatalf("no search is needed to fetch by PMID, have %d", s.searches)
+	}
+}
+
+func TestPrettyPrint(t *testing.T) {
+	c, _ := newTestClient(t)
+	resp, err := c.GetLiterature(context.Background(), lit.Request{Query: "fpga", Page: 2, PerPage: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := c.PrettyPrint(resp.Blobs[0], &buf); err != nil {
+		t.Fatal(err)
+	}
+	if want := "<PubmedArticle>\n\t<MedlineCitation Status=\"MEDLINE\" Owner=\"NLM\">\n\t\t<PMID Version=\"1\">31452104</PMID>"; !strings.HasPrefix(buf.String(), want) {
+		t.Fatalf("pretty print: have\n%s", buf.String())
+	}
+}
+
+func TestSearchError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"header":{"type":"esearch","version":"0.3"},"esearchresult":{"ERROR":"Invalid query"}}`)
+	}))
+	defer srv.Close()
+	c := NewClient("")
+	c.endpoint = srv.URL
+
+	if _, err := c.GetMaxLiterature(context.Background(), lit.Request{Query: "(("}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestConformance(t *testing.T) {
+	// Books, the last record, have no authors to cite.
+	start := strings.Index(efetchResult, "<PubmedArticle>")
+	end := strings.Index(efetchResult, "</PubmedArticle>") + len("</PubmedArticle>")
+	articles := make([]string, 42)
+	for i := range articles {
+		a := strings.ReplaceAll(efetchResult[start:end], "31452104", fmt.Sprintf("3145%04d", i))
+		a = strings.ReplaceAll(a, "2019.2936211", fmt.Sprintf("2019.%d", i))
+		articles[i] = strings.Replace(a, "</ArticleTitle>", fmt.Sprintf(" Part %d.</ArticleTitle>", i), 1)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path == "/esearch.fcgi" {
+			fmt.Fprint(w, strings.Replace(esearchResult, `"count": "57"`, fmt.Sprintf(`"count": "%d"`, len(articles)), 1))
+			return
+		}
+		from, _ := strconv.Atoi(q.Get("retstart"))
+		max, _ := strconv.Atoi(q.Get("retmax"))
+		to := from + max
+		if to > len(articles) {
+			to = len(articles)
+		}
+		fmt.Fprintf(w, "<PubmedArticleSet>\n%s\n</PubmedArticleSet>\n", strings.Join(articles[from:to], "\n"))
+	}))
+	t.Cleanup(srv.Close)
+	c := NewClient("")
+	c.endpoint = srv.URL
+	littest.RunConformance(t, c, littest.Fixture{Query: "fpga[tiab]", PerPage: 10, Max: 42})
+}
+
+const esearchResult = `{
+  "header": {"type": "esearch", "version": "0.3"},
+  "esearchresult": {
+    "count": "57",
+    "retmax": "0",
+    "retstart": "0",
+    "querykey": "1",
+    "webenv": "MCID_61dc6f7e5b1bd2420f1a4a12",
+    "idlist": [],
+    "translationset": [],
+    "querytranslation": "fpga[tiab] AND neural network[tiab]"
+  }
+}`
+
+// Trimmed down response recorded from efetch.fcgi, anonymized.
+const efetchResult = `<?xml version="1.0" ?>
+<!DOCTYPE PubmedArticleSet PUBLIC "-//NLM//DTD PubMedArticle, 1st January 2019//EN" "https://dtd.nlm.nih.gov/ncbi/pubmed/out/pubmed_190101.dtd">
+<PubmedArticleSet>
+<PubmedArticle>
+    <MedlineCitation Status="MEDLINE" Owner="NLM">
+        <PMID Version="1">31452104</PMID>
+        <Article PubModel="Print-Electronic">
+            <Journal>
+                <ISSN IssnType="Electronic">2168-2208</ISSN>
+                <JournalIssue CitedMedium="Internet">
+                    <Volume>23</Volume>
+                    <Issue>5</Issue>
+                    <PubDate>
+                        <Year>2019</Year>
+                        <Month>Aug</Month>
+                    </PubDate>
+                </JournalIssue>
+                <Title>IEEE journal of biomedical and health informatics</Title>
+            </Journal>
+            <ArticleTitle>An <i>FPGA</i>-based accelerator for deep neural networks in clinical imaging.</ArticleTitle>
+            <Pagination>
+                <MedlinePgn>1800-1810</MedlinePgn>
+            </Pagination>
+            <Abstract>
+                <AbstractText Label="BACKGROUND" NlmCategory="BACKGROUND">Deep networks are slow on
+                embedded devices.</AbstractText>
+                <AbstractText Label="METHODS" NlmCategory="METHODS">We designed an accelerator.</AbstractText>
+            </Abstract>
+            <AuthorList CompleteYN="Y">
+                <Author ValidYN="Y">
+                    <LastName>Smith</LastName>
+                    <ForeName>Jane A</ForeName>
+                    <Initials>JA</Initials>
+                    <Identifier Source="ORCID">https://orcid.org/0000-0002-1825-0097</Identifier>
+                    <AffiliationInfo>
+                        <Affiliation>Department of Radiology, Example University.</Affiliation>
+                    </AffiliationInfo>
+                </Author>
+                <Author ValidYN="Y">
+                    <CollectiveName>Medical Imaging Consortium</CollectiveName>
+                </Author>
+            </AuthorList>
+            <Language>eng</Language>
+            <PublicationTypeList>
+                <PublicationType UI="D016428">Journal Article</PublicationType>
+            </PublicationTypeList>
+        </Article>
+        <MeshHeadingList>
+            <MeshHeading>
+                <DescriptorName UI="D006801" MajorTopicYN="N">Humans</DescriptorName>
+            </MeshHeading>
+            <MeshHeading>
+                <DescriptorName UI="D016571" MajorTopicYN="Y">Neural Networks, Computer</DescriptorName>
+                <QualifierName UI="Q000295" MajorTopicYN="N">instrumentation</QualifierName>
+            </MeshHeading>
+        </MeshHeadingList>
+        <KeywordList Owner="NOTNLM">
+            <Keyword MajorTopicYN="N">FPGA</Keyword>
+            <Keyword MajorTopicYN="N">hardware acceleration</Keyword>
+        </KeywordList>
+    </MedlineCitation>
+    <PubmedData>
+        <ArticleIdList>
+            <ArticleId IdType="pubmed">31452104</ArticleId>
+            <ArticleId IdType="doi">10.1109/JBHI.2019.2936211</ArticleId>
+            <ArticleId IdType="pmc">PMC6789012</ArticleId>
+        </ArticleIdList>
+    </PubmedData>
+</PubmedArticle>
+<PubmedBookArticle>
+    <BookDocument>
+        <PMID Version="1">32000001</PMID>
+        <Book>
+            <Publisher><PublisherName>Example Press</PublisherName></Publisher>
+            <BookTitle book="hw">Hardware for neural networks</BookTitle>
+            <PubDate><Year>2020</Year></PubDate>
+        </Book>
+        <Abstract>
+            <AbstractText>A book about accelerators.</AbstractText>
+        </Abstract>
+    </BookDocument>
+</PubmedBookArticle>
+</PubmedArticleSet>
+`
@@ -0,0 +1,343 @@
+// Package query implements a small boolean query language, modeled after
+// the one used by Scopus, to be evaluated locally against documents:
+//
+//	fpga AND (cnn OR "neural network") AND NOT TITLE(survey)
+//
+// Terms are matched case insensitively against whole words, a trailing *
+// matches any word with the given prefix and a lone * matches everything.
+// Quoted phrases, either in double quotes or braces, match consecutive
+// words. Adjacent terms are implicitly joined with AND. FIELD(expr)
+// restricts expr to the named field, interpreted by the Document.
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Document is what queries are evaluated against.
+type Document interface {
+	// Text returns the text of field, or the text of the default fields
+	// when field is empty. Field names are upper case.
+	Text(field string) string
+}
+
+// Expr is a parsed query.
+type Expr interface {
+	Match(Document) bool
+	String() string
+}
+
+type and struct {
+	left, right Expr
+}
+
+func (e and) Match(d Document) bool {
+	return e.left.Match(d) && e.right.Match(d)
+}
+
+func (e and) String() string {
+	return fmt.Sprintf("(%v AND %v)", e.left, e.right)
+}
+
+type or struct {
+	left, right Expr
+}
+
+func (e or) Match(d Document) bool {
+	return e.left.Match(d) || e.right.Match(d)
+}
+
+func (e or) String() string {
+	return fmt.Sprintf("(%v OR %v)", e.left, e.right)
+}
+
+type not struct {
+	expr Expr
+}
+
+func (e not) Match(d Document) bool {
+	return !e.expr.Match(d)
+}
+
+func (e not) String() string {
+	return fmt.Sprintf("NOT %v", e.expr)
+}
+
+// field forwards the field restriction to the terms below it.
+type field struct {
+	name string
+	expr Expr
+}
+
+type fieldDocument struct {
+	Document
+	name string
+}
+
+func (d fieldDocument) Text(string) string {
+	return d.Document.Text(d.name)
+}
+
+func (e field) Match(d Document) bool {
+	// The innermost field wins.
+	if fd, ok := d.(fieldDocument); ok {
+		d = fd.Document
+	}
+	return e.expr.Match(fieldDocument{Document: d, name: e.name})
+}
+
+func (e field) String() string {
+	return fmt.Sprintf("%s(%v)", e.name, e.expr)
+}
+
+type term struct {
+	words  []string
+	prefix bool
+}
+
+func (e term) Match(d Document) bool {
+	if len(e.words) == 0 {
+		return true
+	}
+	return containsWords(Words(d.Text("")), e.words, e.prefix)
+}
+
+func (e term) String() string {
+	s := strings.Join(e.words, " ")
+	if len(e.words) == 0 {
+		return "*"
+	}
+	if e.prefix {
+		s += "*"
+	}
+	if len(e.words) > 1 {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+func containsWords(text, words []string, prefix bool) bool {
+	last := len(words) - 1
+	for i := 0; i+len(words) <= len(text); i++ {
+		ok := true
+		for j, w := range words {
+			t := text[i+j]
+			if j == last && prefix {
+				ok = strings.HasPrefix(t, w)
+			} else {
+				ok = t == w
+			}
+			if !ok {
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Words splits s in lower case words, dropping punctuation.
+func Words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenPhrase
+	tokenOpen
+	tokenClose
+	tokenEOF
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func tokenize(s string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpen, pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenClose, pos: i})
+			i++
+		case r == '"' || r == '{':
+			end := '"'
+			if r == '{' {
+				end = '}'
+			}
+			j := i + 1
+			for j < len(runes) && runes[j] != end {
+				j++
+			}
+			if j == len(runes) {
+				return nil, fmt.Errorf("unterminated phrase at %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenPhrase, value: string(runes[i+1 : j]), pos: i})
+			i = j + 1
+		default:
+			j := i
+			for j < len(runes) && !unicode.IsSpace(runes[j]) && runes[j] != '(' && runes[j] != ')' && runes[j] != '"' && runes[j] != '{' {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[i:j]), pos: i})
+			i = j
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func isKeyword(t token, k string) bool {
+	return t.kind == tokenWord && t.value == k
+}
+
+// Parse parses a query. Operators must be upper case, so that lower case
+// "and", "or" and "not" are searched for as any other word.
+func Parse(s string) (Expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, fmt.Errorf("empty query")
+	}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", t.value, t.pos)
+	}
+	return e, nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = or{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case isKeyword(t, "AND"):
+			p.next()
+		case t.kind == tokenWord && t.value != "OR", t.kind == tokenPhrase, t.kind == tokenOpen:
+			// Implicit AND.
+		default:
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = and{left, right}
+	}
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if isKeyword(p.peek(), "NOT") {
+		p.next()
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return not{e}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenOpen:
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokenClose {
+			return nil, fmt.Errorf("missing closing parenthesis for the one at %d", t.pos)
+		}
+		return e, nil
+	case tokenPhrase:
+		return term{words: Words(t.value)}, nil
+	case tokenWord:
+		if t.value == "AND" || t.value == "OR" {
+			return nil, fmt.Errorf("unexpected %s at %d", t.value, t.pos)
+		}
+		if p.peek().kind == tokenOpen {
+			// FIELD(expr)
+			p.next()
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if c := p.next(); c.kind != tokenClose {
+				return nil, fmt.Errorf("missing closing parenthesis for field %s at %d", t.value, t.pos)
+			}
+			return field{name: strings.ToUpper(t.value), expr: e}, nil
+		}
+		if t.value == "*" {
+			return term{}, nil
+		}
+		prefix := strings.HasSuffix(t.value, "*")
+		words := Words(t.value)
+		if len(words) == 0 {
+			return nil, fmt.Errorf("term %q at %d has no words", t.value, t.pos)
+		}
+		return term{words: words, prefix: prefix}, nil
+	case tokenClose:
+		return nil, fmt.Errorf("unexpected closing parenthesis at %d", t.pos)
+	default:
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+}
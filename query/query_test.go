@@ -0,0 +1,69 @@
+package query
+
+import "testing"
+
+type doc map[string]string
+
+func (d doc) Text(field string) string {
+	if field == "" {
+		return d["TITLE"] + " " + d["ABS"]
+	}
+	return d[field]
+}
+
+func TestMatch(t *testing.T) {
+	d := doc{
+		"TITLE": "Can FPGAs beat GPUs in accelerating next-generation deep neural networks?",
+		"ABS":   "We evaluate CNN inference on an Intel Stratix 10.",
+	}
+	tt := []struct {
+		query string
+		match bool
+	}{
+		{"fpga", false},
+		{"fpga*", true},
+		{"FPGAs", true},
+		{"cnn gpus", true},
+		{"cnn AND survey", false},
+		{"cnn OR survey", true},
+		{`"deep neural networks"`, true},
+		{`{neural deep}`, false},
+		{`"next generation"`, true},
+		{"fpga* AND NOT survey", true},
+		{"NOT (cnn OR survey)", false},
+		{"TITLE(cnn)", false},
+		{"ABS(cnn) AND TITLE(gpus)", true},
+		{"TITLE(ABS(stratix))", true},
+		{"(fpga* AND (nn OR dnn OR cnn OR \"neural network*\") AND gpu*)", true},
+		{"nn", false},
+		{"*", true},
+		{"NOT *", false},
+	}
+	for _, v := range tt {
+		e, err := Parse(v.query)
+		if err != nil {
+			t.Errorf("%s: %v", v.query, err)
+			continue
+		}
+		if have := e.Match(d); have != v.match {
+			t.Errorf("%s (parsed as %v): have %v, want %v", v.query, e, have, v.match)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, v := range []string{
+		"",
+		"(fpga",
+		"fpga)",
+		"fpga AND",
+		"OR fpga",
+		`"fpga`,
+		"TITLE(fpga",
+		"fpga AND OR gpu",
+	} {
+		if _, err := Parse(v); err == nil {
+			t.Errorf("%q: expected an error", v)
+		}
+	}
+}
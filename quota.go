@@ -0,0 +1,184 @@
+package lit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Quota is a snapshot of the requests an API key is still allowed to
+// make, as reported by the library.
+type Quota struct {
+	Limit     int
+	Remaining int
+	// Reset is when Remaining goes back to Limit, zero when unknown.
+	Reset time.Time
+	// Time is when the snapshot was taken.
+	Time time.Time
+}
+
+// ParseQuota reads the X-RateLimit-Limit, X-RateLimit-Remaining and
+// X-RateLimit-Reset headers of h, the latter in seconds since the epoch.
+// It returns false when h does not carry them.
+func ParseQuota(h http.Header, now time.Time) (Quota, bool) {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return Quota{}, false
+	}
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return Quota{}, false
+	}
+	q := Quota{Limit: limit, Remaining: remaining, Time: now}
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil && reset > 0 {
+		q.Reset = time.Unix(reset, 0)
+	}
+	return q, true
+}
+
+// At returns the quota left at t, which is the whole limit once the quota
+// was reset.
+func (q Quota) At(t time.Time) Quota {
+	if !q.Reset.IsZero() && !t.Before(q.Reset) {
+		q.Remaining = q.Limit
+		q.Reset = time.Time{}
+	}
+	return q
+}
+
+// Equal tells whether q and o report the same quota, regardless of when.
+func (q Quota) Equal(o Quota) bool {
+	return q.Limit == o.Limit && q.Remaining == o.Remaining && q.Reset.Equal(o.Reset)
+}
+
+func (q Quota) String() string {
+	s := fmt.Sprintf("%s of %s requests left", thousands(q.Remaining), thousands(q.Limit))
+	if !q.Reset.IsZero() {
+		s += ", resets " + q.Reset.Local().Format("Mon Jan 2 15:04")
+	}
+	return s
+}
+
+// thousands formats n with comma separated thousands.
+func thousands(n int) string {
+	if n < 0 {
+		return "-" + thousands(-n)
+	}
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// Marshal encodes q as event data, along with the name of its library.
+func (q Quota) Marshal(library string) []string {
+	reset := ""
+	if !q.Reset.IsZero() {
+		reset = strconv.FormatInt(q.Reset.Unix(), 10)
+	}
+	return []string{
+		library,
+		strconv.Itoa(q.Limit),
+		strconv.Itoa(q.Remaining),
+		reset,
+		strconv.FormatInt(q.Time.Unix(), 10),
+	}
+}
+
+// UnmarshalQuota decodes data encoded by Quota.Marshal, returning the
+// name of the library too.
+func UnmarshalQuota(data []string) (string, Quota, error) {
+	if len(data) < 5 {
+		return "", Quota{}, fmt.Errorf("quota: have %d fields, want 5", len(data))
+	}
+	var (
+		q   Quota
+		err error
+	)
+	if q.Limit, err = strconv.Atoi(data[1]); err != nil {
+		return "", Quota{}, fmt.Errorf("quota: limit conversion: %w", err)
+	}
+	if q.Remaining, err = strconv.Atoi(data[2]); err != nil {
+		return "", Quota{}, fmt.Errorf("quota: remaining conversion: %w", err)
+	}
+	if data[3] != "" {
+		reset, err := strconv.ParseInt(data[3], 10, 64)
+		if err != nil {
+			return "", Quota{}, fmt.Errorf("quota: reset conversion: %w", err)
+		}
+		q.Reset = time.Unix(reset, 0)
+	}
+	t, err := strconv.ParseInt(data[4], 10, 64)
+	if err != nil {
+		return "", Quota{}, fmt.Errorf("quota: time conversion: %w", err)
+	}
+	q.Time = time.Unix(t, 0)
+	return data[0], q, nil
+}
+
+// QuotaReporter is implemented by libraries whose API keys have a quota.
+type QuotaReporter interface {
+	// Quota returns the latest snapshot of the quota, false when no
+	// response carried one yet.
+	Quota() (Quota, bool)
+}
+
+// QuotaTracker keeps the latest quota reported by the responses of a
+// library. It is safe for concurrent use.
+type QuotaTracker struct {
+	mu    sync.Mutex
+	quota Quota
+	ok    bool
+}
+
+// Observe records the quota carried by r, if any.
+func (t *QuotaTracker) Observe(r *http.Response) {
+	q, ok := ParseQuota(r.Header, time.Now())
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.quota, t.ok = q, true
+}
+
+// Quota implements QuotaReporter.
+func (t *QuotaTracker) Quota() (Quota, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.quota, t.ok
+}
+
+// CheckQuota returns an error matching ErrQuotaExceeded when the sources
+// of each QuotaReporter library take more requests than it has left.
+func CheckQuota(sources ...Source) error {
+	needed := make(map[string]int)
+	libs := make(map[string]QuotaReporter)
+	names := []string{}
+	for _, v := range sources {
+		var r QuotaReporter
+		if !As(v.Library, &r) {
+			continue
+		}
+		name := v.Library.GetName()
+		if _, ok := libs[name]; !ok {
+			names = append(names, name)
+		}
+		libs[name] = r
+		needed[name] += v.Requests()
+	}
+	for _, name := range names {
+		q, ok := libs[name].Quota()
+		if !ok {
+			continue
+		}
+		q = q.At(time.Now())
+		if needed[name] > q.Remaining {
+			return fmt.Errorf("%s: %w: the download takes %s requests, %v", name, ErrQuotaExceeded, thousands(needed[name]), q)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package lit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// StatusError is returned by libraries when a request fails with an
+// unexpected HTTP status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	// Message is the explanation provided by the library, if any.
+	Message string
+	// RetryAfter is the delay asked by the library before trying again,
+	// zero when not provided.
+	RetryAfter time.Duration
+	// Kind is the class of the error, e.g. ErrNotEntitled, nil when
+	// unknown.
+	Kind error
+}
+
+// NewStatusError builds a StatusError out of r, explained by msg. Its Kind
+// is guessed from the status code: libraries telling apart, e.g., an
+// exhausted quota from a rate limit override it.
+func NewStatusError(r *http.Response, msg string) *StatusError {
+	return &StatusError{
+		StatusCode: r.StatusCode,
+		Status:     r.Status,
+		Message:    msg,
+		RetryAfter: parseRetryAfter(r.Header.Get("Retry-After"), time.Now()),
+		Kind:       statusKind(r.StatusCode),
+	}
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return e.Status
+	}
+	return e.Status + ": " + e.Message
+}
+
+// Unwrap returns the class of the error.
+func (e *StatusError) Unwrap() error {
+	return e.Kind
+}
+
+// Temporary tells whether the request might succeed if tried again.
+func (e *StatusError) Temporary() bool {
+	if e.Kind == ErrQuotaExceeded {
+		return false
+	}
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is
+// either a number of seconds or a date.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if s, err := strconv.Atoi(v); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// IsTemporary tells whether err is worth a retry: HTTP 429 and 5xx
+// responses, timeouts and transient network errors are.
+func IsTemporary(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var t interface{ Temporary() bool }
+	if errors.As(err, &t) && t.Temporary() {
+		return true
+	}
+	var to interface{ Timeout() bool }
+	return errors.As(err, &to) && to.Timeout()
+}
+
+// RetryPolicy tells how many times, and how often, a page that failed with
+// a temporary error is requested again.
+type RetryPolicy struct {
+	Retries    int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is used by searches.
+var DefaultRetryPolicy = RetryPolicy{
+	Retries:    4,
+	MinBackoff: time.Second,
+	MaxBackoff: time.Second * 30,
+}
+
+// Backoff returns the delay before retry number attempt, starting from 0,
+// of a request that failed with err: it doubles at each attempt, with some
+// jitter, unless the library asked for a longer one.
+func (p RetryPolicy) Backoff(attempt int, err error) time.Duration {
+	d := p.MinBackoff << uint(attempt)
+	if d > p.MaxBackoff || d <= 0 {
+		d = p.MaxBackoff
+	}
+	if d > 1 {
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)))
+	}
+	var se *StatusError
+	if errors.As(err, &se) && se.RetryAfter > d {
+		d = se.RetryAfter
+	}
+	return d
+}
+
+// PageError tells that a page could not be downloaded, even after retrying.
+type PageError struct {
+	Page     int
+	Attempts int
+	Err      error
+}
+
+func (e *PageError) Error() string {
+	if e.Attempts > 1 {
+		return fmt.Sprintf("page %d: %v (%d attempts)", e.Page, e.Err, e.Attempts)
+	}
+	return fmt.Sprintf("page %d: %v", e.Page, e.Err)
+}
+
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// ConcurrencyLimiter is implemented by libraries that accept more than one
+// request at a time.
+type ConcurrencyLimiter interface {
+	// ConcurrencyLimit returns the maximum number of requests that might
+	// be in flight at the same time.
+	ConcurrencyLimit() int
+}
+
+// Do calls f until it succeeds, fails with an error that is not temporary
+// or the retries are over, returning the number of attempts made along
+// with the last error.
+func (p RetryPolicy) Do(ctx context.Context, f func() error) (int, error) {
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return attempt - 1, err
+		}
+		err := f()
+		if err == nil {
+			return attempt, nil
+		}
+		if attempt > p.Retries || !IsTemporary(err) {
+			return attempt, err
+		}
+		select {
+		case <-time.After(p.Backoff(attempt-1, err)):
+		case <-ctx.Done():
+			return attempt, err
+		}
+	}
+}
+
+// getPage runs r, retrying according to policy.
+func getPage(ctx context.Context, lib Library, r Request, policy RetryPolicy) (Response, error) {
+	var resp Response
+	attempts, err := policy.Do(ctx, func() error {
+		var err error
+		resp, err = lib.GetLiterature(ctx, r)
+		return err
+	})
+	if err != nil {
+		return Response{}, &PageError{Page: r.Page, Attempts: attempts, Err: err}
+	}
+	return resp, nil
+}
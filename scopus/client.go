@@ -4,15 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
-	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/jecoz/lit"
@@ -26,39 +27,117 @@ const (
 )
 
 const (
-	KeyLinkAbstract    = "link_abstract"
+	KeyLinkAbstract    = lit.KeyLinkAbstract
 	KeyEid             = "eid"
-	KeyIssn            = "issn"
-	KeyDOI             = "doi"
-	KeyPageRange       = "page_range"
-	KeyVolume          = "volume"
-	KeyPublicationName = "publication_name"
+	KeyIssn            = lit.KeyIssn
+	KeyDOI             = lit.KeyDOI
+	KeyPageRange       = lit.KeyPageRange
+	KeyVolume          = lit.KeyVolume
+	KeyPublicationName = lit.KeyPublicationName
 	KeyArticleNumber   = "article_number"
 	KeyAggregationType = "aggregation_type"
 	KeySubtype         = "subtype"
 	KeyCitedByCount    = "cited_by_count"
 	KeyAffiliation     = "affiliation"
+
+	// Only available with the COMPLETE view.
+	KeyAuthors            = lit.KeyAuthors
+	KeyAuthorIDs          = "author_ids"
+	KeyAuthorAffiliations = "author_affiliations"
+	KeySourceKeywords     = "source_keywords"
+	KeySubjectAreas       = "subject_areas"
+	KeyFundingSponsor     = "funding_sponsor"
+	KeyFundingAcronym     = "funding_acronym"
+	KeyFundingNumber      = "funding_number"
+)
+
+// Views of the Search API, telling which fields are returned.
+const (
+	ViewStandard = "STANDARD"
+	ViewComplete = "COMPLETE"
 )
 
+// offsetLimit is the maximum start+count accepted by the Search API:
+// results past it can only be reached through cursors.
+const offsetLimit = 5000
+
 type Client struct {
-	apiKey     string
-	httpClient *http.Client
+	apiKey           string
+	endpoint         string
+	abstractEndpoint string
+	view             string
+	fields           []string
+	httpClient       *http.Client
+
+	// standardOnly is set once the key turned out not to be entitled to
+	// the view requested, shared among the copies of the client.
+	standardOnly *int32
+	// quota is updated by every response, shared among the copies of the
+	// client as well.
+	quota *lit.QuotaTracker
+}
+
+// do runs req, keeping track of the quota left to the key.
+func (c Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err == nil && c.quota != nil {
+		c.quota.Observe(resp)
+	}
+	return resp, err
+}
+
+// Quota returns the weekly quota left to the key as of the latest
+// response, see lit.QuotaReporter.
+func (c Client) Quota() (lit.Quota, bool) {
+	if c.quota == nil {
+		return lit.Quota{}, false
+	}
+	return c.quota.Quota()
+}
+
+// WithView returns a copy of c requesting view, ViewComplete by default.
+// Keys not entitled to it fall back to ViewStandard.
+func (c Client) WithView(view string) Client {
+	c.view = view
+	return c
+}
+
+// WithFields returns a copy of c requesting only fields, e.g. "dc:title"
+// and "authkeywords", along with the view.
+func (c Client) WithFields(fields ...string) Client {
+	c.fields = fields
+	return c
+}
+
+func (c Client) currentView() string {
+	if c.view == "" || (c.standardOnly != nil && atomic.LoadInt32(c.standardOnly) == 1) {
+		return ViewStandard
+	}
+	return c.view
 }
 
 func (c Client) DefaultPerPage() int {
 	return 25
 }
 
-func newSearchRequest(ctx context.Context, apiKey string, src lit.Request) *http.Request {
-	u, err := url.Parse(searchEndpoint)
+func (c Client) newSearchRequest(ctx context.Context, src lit.Request, view string) *http.Request {
+	u, err := url.Parse(c.endpoint)
 	if err != nil {
 		panic(err)
 	}
 	q := u.Query()
 
 	q.Set("query", src.Query)
+	q.Set("view", view)
+	if len(c.fields) > 0 {
+		q.Set("field", strings.Join(c.fields, ","))
+	}
 	q.Set("count", fmt.Sprintf("%d", src.PerPage))
-	q.Set("start", fmt.Sprintf("%d", src.PerPage*src.Page))
+	if src.Cursor != "" {
+		q.Set("cursor", src.Cursor)
+	} else {
+		q.Set("start", fmt.Sprintf("%d", src.PerPage*src.Page))
+	}
 	u.RawQuery = q.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
@@ -66,18 +145,31 @@ func newSearchRequest(ctx context.Context, apiKey string, src lit.Request) *http
 		panic(err)
 	}
 
-	req.Header.Set("X-ELS-APIKey", apiKey)
+	req.Header.Set("X-ELS-APIKey", c.apiKey)
 	req.Header.Set("Accept", "application/json")
 
 	return req
 }
 
+// extractError maps the status of r, detailed by its X-Els-Status header,
+// e.g. "QUOTA_EXCEEDED - Quota Exceeded", onto the errors of lit.
 func extractError(r *http.Response) error {
-	if msg := r.Header.Get("X-Els-Status"); msg != "" {
-		return fmt.Errorf("%s", msg)
-	}
-
-	return fmt.Errorf("%s", r.Status)
+	status := r.Header.Get("X-Els-Status")
+	err := lit.NewStatusError(r, status)
+	switch {
+	case strings.HasPrefix(status, "QUOTA_EXCEEDED"):
+		err.Kind = lit.ErrQuotaExceeded
+	case r.StatusCode == http.StatusTooManyRequests && r.Header.Get("X-RateLimit-Remaining") == "0":
+		err.Kind = lit.ErrQuotaExceeded
+	case strings.HasPrefix(status, "AUTHORIZATION_ERROR"):
+		// Scopus replies 401 to keys not entitled to a view.
+		err.Kind = lit.ErrNotEntitled
+	case strings.HasPrefix(status, "AUTHENTICATION_ERROR"), strings.HasPrefix(status, "INVALID_API_KEY"):
+		err.Kind = lit.ErrUnauthorized
+	case strings.HasPrefix(status, "RESOURCE_NOT_FOUND"):
+		err.Kind = lit.ErrNotFound
+	}
+	return err
 }
 
 type openSearchLink struct {
@@ -86,28 +178,67 @@ type openSearchLink struct {
 }
 
 type openAffiliation struct {
+	ID      string `json:"afid"`
 	Name    string `json:"affilname"`
 	City    string `json:"affiliation-city"`
 	Country string `json:"affiliation-country"`
 }
 
 type openSearchEntry struct {
-	Title           string `json:"dc:title"`
-	Eid             string `json:"eid"`
-	CoverDateRaw    string `json:"prism:coverDate"`
-	Creator         string `json:"dc:creator"`
-	Issn            string `json:"prism:issn"`
-	DOI             string `json:"prism:doi"`
-	PageRange       string `json:"prism:pageRange"`
-	Volume          string `json:"prism:volume"`
-	PublicationName string `json:"prism:publicationName"`
-	ArticleNumber   string `json:"article-number"`
-	AggregationType string `json:"prism:aggregationType"`
-	Subtype         string `json:"subtype"`
-	CitedByCount    string `json:"citedby-count"`
+	Title           string      `json:"dc:title"`
+	Eid             string      `json:"eid"`
+	CoverDateRaw    string      `json:"prism:coverDate"`
+	Creator         string      `json:"dc:creator"`
+	Issn            string      `json:"prism:issn"`
+	DOI             string      `json:"prism:doi"`
+	PageRange       string      `json:"prism:pageRange"`
+	Volume          string      `json:"prism:volume"`
+	Issue           string      `json:"prism:issueIdentifier"`
+	ISBN            openStrings `json:"prism:isbn"`
+	PublicationName string      `json:"prism:publicationName"`
+	ArticleNumber   string      `json:"article-number"`
+	AggregationType string      `json:"prism:aggregationType"`
+	Subtype         string      `json:"subtype"`
+	CitedByCount    string      `json:"citedby-count"`
 
 	Links        []openSearchLink  `json:"link"`
 	Affiliations []openAffiliation `json:"affiliation"`
+
+	// COMPLETE view only.
+	PubmedID     string            `json:"pubmed-id"`
+	Description  string            `json:"dc:description"`
+	AuthKeywords string            `json:"authkeywords"`
+	Authors      []openAuthor      `json:"author"`
+	SubjectAreas []openSubjectArea `json:"subject-area"`
+	FundAcronym  string            `json:"fund-acr"`
+	FundNumber   string            `json:"fund-no"`
+	FundSponsor  string            `json:"fund-sponsor"`
+}
+
+type openValue struct {
+	Value string `json:"$"`
+}
+
+type openAuthor struct {
+	ID        string      `json:"authid"`
+	Name      string      `json:"authname"`
+	Surname   string      `json:"surname"`
+	GivenName string      `json:"given-name"`
+	ORCID     string      `json:"orcid"`
+	Afids     []openValue `json:"afid"`
+}
+
+// BibTeXName returns the name of the author in "Surname, Given Name" form.
+func (a openAuthor) BibTeXName() string {
+	if a.Surname != "" && a.GivenName != "" {
+		return a.Surname + ", " + a.GivenName
+	}
+	return a.Name
+}
+
+type openSubjectArea struct {
+	Abbrev string `json:"@abbrev"`
+	Name   string `json:"$"`
 }
 
 func (e openSearchEntry) CoverDate() (time.Time, error) {
@@ -143,11 +274,152 @@ func (e openSearchEntry) Affiliation() string {
 	return strings.Join(affiliations, "; ")
 }
 
+// AuthorValues returns the full author list in BibTeX form, their Scopus
+// IDs and their affiliations, in the same order.
+func (e openSearchEntry) AuthorValues() (string, string, string) {
+	affiliations := make(map[string]string, len(e.Affiliations))
+	for _, v := range e.Affiliations {
+		affiliations[v.ID] = v.Name
+	}
+	names := make([]string, len(e.Authors))
+	ids := make([]string, len(e.Authors))
+	afs := make([]string, len(e.Authors))
+	for i, v := range e.Authors {
+		names[i] = v.BibTeXName()
+		ids[i] = v.ID
+		af := []string{}
+		for _, id := range v.Afids {
+			if name := affiliations[id.Value]; name != "" {
+				af = append(af, name)
+			}
+		}
+		afs[i] = strings.Join(af, ", ")
+	}
+	if len(e.Authors) == 0 {
+		return "", "", ""
+	}
+	return strings.Join(names, " and "), strings.Join(ids, "; "), strings.Join(afs, "; ")
+}
+
+// AuthorList returns the authors of the entry along with the names of
+// their affiliations, empty but with the COMPLETE view.
+func (e openSearchEntry) AuthorList() []lit.Author {
+	if len(e.Authors) == 0 {
+		return nil
+	}
+	affiliations := make(map[string]string, len(e.Affiliations))
+	for _, v := range e.Affiliations {
+		affiliations[v.ID] = v.Name
+	}
+	authors := make([]lit.Author, len(e.Authors))
+	for i, v := range e.Authors {
+		af := []string{}
+		for _, id := range v.Afids {
+			if name := affiliations[id.Value]; name != "" {
+				af = append(af, name)
+			}
+		}
+		authors[i] = lit.Author{
+			Name:        v.BibTeXName(),
+			ORCID:       v.ORCID,
+			Affiliation: strings.Join(af, ", "),
+		}
+	}
+	return authors
+}
+
+func (e openSearchEntry) KeywordList() []string {
+	var keywords []string
+	for _, v := range strings.Split(e.AuthKeywords, "|") {
+		if v = strings.TrimSpace(v); v != "" {
+			keywords = append(keywords, v)
+		}
+	}
+	return keywords
+}
+
+func (e openSearchEntry) Keywords() string {
+	return strings.Join(e.KeywordList(), ", ")
+}
+
+// DocumentType maps the subtype of the entry, e.g. "ar" for articles,
+// onto the types of lit.
+func (e openSearchEntry) DocumentType() lit.DocumentType {
+	switch e.Subtype {
+	case "ar", "le", "no", "ed", "er":
+		return lit.TypeArticle
+	case "re", "sh", "cr":
+		return lit.TypeReview
+	case "cp":
+		return lit.TypeConferencePaper
+	case "bk":
+		return lit.TypeBook
+	case "ch":
+		return lit.TypeBookChapter
+	case "":
+		return ""
+	default:
+		return lit.TypeOther
+	}
+}
+
+func (e openSearchEntry) CitedBy() *int {
+	n, err := strconv.Atoi(e.CitedByCount)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// Publication returns the entry as a lit.Publication.
+func (e openSearchEntry) Publication() (lit.Publication, error) {
+	coverDate, err := e.CoverDate()
+	if err != nil {
+		return lit.Publication{}, err
+	}
+	p := lit.Publication{
+		Title:     e.Title,
+		CoverDate: coverDate,
+		Creator:   e.Creator,
+		Authors:   e.AuthorList(),
+		IDs: lit.Identifiers{
+			DOI:  e.DOI,
+			EID:  e.Eid,
+			PMID: e.PubmedID,
+		},
+		Venue:          e.PublicationName,
+		Volume:         e.Volume,
+		Issue:          e.Issue,
+		Pages:          e.PageRange,
+		Type:           e.DocumentType(),
+		CitedBy:        e.CitedBy(),
+		SourceKeywords: e.KeywordList(),
+		Values:         e.Values(),
+	}
+	if len(e.ISBN) > 0 {
+		p.IDs.ISBN = e.ISBN[0]
+	}
+	// Abstracts come along with the COMPLETE view.
+	if e.Description != "" {
+		p.Abstract = &lit.Abstract{Text: e.Description}
+	}
+	return p, nil
+}
+
+func (e openSearchEntry) SubjectAreaNames() string {
+	names := make([]string, len(e.SubjectAreas))
+	for i, v := range e.SubjectAreas {
+		names[i] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
+
 func (e openSearchEntry) Values() map[string]string {
 	links := make(map[string]string)
 	for _, v := range e.Links {
 		links[v.Tag] = v.Ref
 	}
+	authors, authorIDs, authorAffiliations := e.AuthorValues()
 
 	return map[string]string{
 		KeyLinkAbstract:    links["scopus"],
@@ -162,82 +434,203 @@ func (e openSearchEntry) Values() map[string]string {
 		KeySubtype:         e.Subtype,
 		KeyCitedByCount:    e.CitedByCount,
 		KeyAffiliation:     e.Affiliation(),
+
+		KeyAuthors:            authors,
+		KeyAuthorIDs:          authorIDs,
+		KeyAuthorAffiliations: authorAffiliations,
+		KeySourceKeywords:     e.Keywords(),
+		KeySubjectAreas:       e.SubjectAreaNames(),
+		KeyFundingSponsor:     e.FundSponsor,
+		KeyFundingAcronym:     e.FundAcronym,
+		KeyFundingNumber:      e.FundNumber,
 	}
 }
 
 func mapPublications(entries []openSearchEntry) ([]lit.Publication, error) {
 	pubs := make([]lit.Publication, len(entries))
 	for i, v := range entries {
-		coverDate, err := v.CoverDate()
+		p, err := v.Publication()
 		if err != nil {
 			return pubs, fmt.Errorf("search result %d, %s: %w", i, v.Eid, err)
 		}
-
-		pubs[i] = lit.Publication{
-			Title:     v.Title,
-			CoverDate: coverDate,
-			Creator:   v.Creator,
-			Values:    v.Values(),
-		}
+		pubs[i] = p
 	}
 	return pubs, nil
 }
 
-func (c Client) GetLiterature(ctx context.Context, req lit.Request) (lit.Response, error) {
-	search := newSearchRequest(ctx, c.apiKey, req)
-	resp, err := c.httpClient.Do(search)
+// searchView runs src requesting view, decoding the response in v.
+func (c Client) searchView(ctx context.Context, src lit.Request, view string, v interface{}) error {
+	resp, err := c.do(c.newSearchRequest(ctx, src, view))
 	if err != nil {
-		return lit.Response{}, err
+		return err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return lit.Response{}, extractError(resp)
+		return extractError(resp)
 	}
-	defer resp.Body.Close()
+	return lit.Malformed(json.NewDecoder(resp.Body).Decode(v))
+}
+
+// search runs src, falling back to the STANDARD view when the key is not
+// entitled to the one requested.
+func (c Client) search(ctx context.Context, src lit.Request, v interface{}) error {
+	view := c.currentView()
+	err := c.searchView(ctx, src, view, v)
+	if view == ViewStandard || !errors.Is(err, lit.ErrNotEntitled) {
+		return err
+	}
+	if err := c.searchView(ctx, src, ViewStandard, v); err != nil {
+		// Not a matter of entitlement.
+		return err
+	}
+	if c.standardOnly != nil {
+		atomic.StoreInt32(c.standardOnly, 1)
+	}
+	return nil
+}
 
+func (c Client) GetLiterature(ctx context.Context, req lit.Request) (lit.Response, error) {
 	var p struct {
 		Results struct {
 			Entries []json.RawMessage `json:"entry"`
+			Cursor  struct {
+				Next string `json:"@next"`
+			} `json:"cursor"`
 		} `json:"search-results"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+	if err := c.search(ctx, req, &p); err != nil {
 		return lit.Response{}, err
 	}
-	blobs := make([]lit.Blob, len(p.Results.Entries))
-	for i, v := range p.Results.Entries {
-		blobs[i] = lit.Blob(v)
+	blobs := make([]lit.Blob, 0, len(p.Results.Entries))
+	for _, v := range p.Results.Entries {
+		// Empty result sets come with a single entry telling so.
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(v, &e) == nil && e.Error != "" {
+			continue
+		}
+		blobs = append(blobs, lit.Blob(v))
 	}
-	return lit.Response{
+	res := lit.Response{
 		Req:   req,
 		Blobs: blobs,
-	}, nil
+	}
+	if req.Cursor != "" {
+		res.Next = p.Results.Cursor.Next
+	}
+	return res, nil
 }
 
-func (c Client) GetMaxLiterature(ctx context.Context, req lit.Request) (int, error) {
-	search := newSearchRequest(ctx, c.apiKey, req)
-	resp, err := c.httpClient.Do(search)
-	if err != nil {
-		return 0, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return 0, extractError(resp)
+// ResultLimit returns the number of results reachable through start and
+// count, past which queries are better sliced by year, see lit.Slicer.
+func (c Client) ResultLimit() int {
+	return offsetLimit
+}
+
+// SliceQuery restricts query to the publications of the years from to to
+// through PUBYEAR clauses, see lit.Slicer.
+func (c Client) SliceQuery(query string, from, to int) string {
+	switch {
+	case from == 0:
+		return fmt.Sprintf("(%s) AND PUBYEAR < %d", query, to+1)
+	case to == 0:
+		return fmt.Sprintf("(%s) AND PUBYEAR > %d", query, from-1)
+	case from == to:
+		return fmt.Sprintf("(%s) AND PUBYEAR IS %d", query, from)
+	default:
+		return fmt.Sprintf("(%s) AND PUBYEAR > %d AND PUBYEAR < %d", query, from-1, to+1)
 	}
-	defer resp.Body.Close()
+}
+
+// OffsetLimit returns the number of results reachable through start and
+// count, see lit.CursorPager.
+func (c Client) OffsetLimit() int {
+	return offsetLimit
+}
 
+func (c Client) GetMaxLiterature(ctx context.Context, req lit.Request) (int, error) {
 	var p struct {
 		Results struct {
 			Total string `json:"opensearch:totalResults"`
 		} `json:"search-results"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+	if err := c.search(ctx, req, &p); err != nil {
 		return 0, err
 	}
 	n, err := strconv.Atoi(p.Results.Total)
 	if err != nil {
-		return 0, fmt.Errorf("unexpected result field: %w", err)
+		return 0, fmt.Errorf("unexpected result field: %w", lit.Malformed(err))
 	}
 	return n, nil
 }
 
+type facetCategory struct {
+	Value    string `json:"value"`
+	HitCount string `json:"hitCount"`
+}
+
+// facetCategories decodes the categories of a facet, which Elsevier
+// encodes as a single object when there is only one.
+type facetCategories []facetCategory
+
+func (f *facetCategories) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+	var v []facetCategory
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// Facets breaks the results of req down by year of publication through
+// the pubyear facet of the Search API, see lit.FacetProvider.
+func (c Client) Facets(ctx context.Context, req lit.Request, field string) ([]lit.Facet, error) {
+	if field != lit.FacetYear {
+		return nil, fmt.Errorf("facet %q: %w", field, lit.ErrUnsupported)
+	}
+	req.Page = 0
+	req.PerPage = 0
+	req.Cursor = ""
+	r := c.newSearchRequest(ctx, req, ViewStandard)
+	q := r.URL.Query()
+	q.Set("facets", "pubyear(count=200,sort=na)")
+	r.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, extractError(resp)
+	}
+	var p struct {
+		Results struct {
+			Facet struct {
+				Category facetCategories `json:"category"`
+			} `json:"facet"`
+		} `json:"search-results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return nil, lit.Malformed(err)
+	}
+	facets := make([]lit.Facet, 0, len(p.Results.Facet.Category))
+	for _, v := range p.Results.Facet.Category {
+		n, err := strconv.Atoi(v.HitCount)
+		if err != nil {
+			return nil, fmt.Errorf("facet %s: %w", v.Value, lit.Malformed(err))
+		}
+		facets = append(facets, lit.Facet{Value: v.Value, Count: n})
+	}
+	sort.Slice(facets, func(i, j int) bool { return facets[i].Value < facets[j].Value })
+	return facets, nil
+}
+
 func (c Client) GetRateLimit() time.Duration {
 	// https://dev.elsevier.com/api_key_settings.html
 	return time.Millisecond * 1000 / time.Duration(6)
@@ -256,17 +649,11 @@ func (c Client) ParsePublication(b lit.Blob) (lit.Publication, error) {
 	if err := json.Unmarshal([]byte(b), &entry); err != nil {
 		return lit.Publication{}, err
 	}
-	coverDate, err := entry.CoverDate()
+	p, err := entry.Publication()
 	if err != nil {
 		return lit.Publication{}, fmt.Errorf("search result %s: %w", entry.Eid, err)
 	}
-
-	return lit.Publication{
-		Title:     entry.Title,
-		CoverDate: coverDate,
-		Creator:   entry.Creator,
-		Values:    entry.Values(),
-	}, nil
+	return p, nil
 }
 
 func (c Client) GetLink(ctx context.Context, link string) (io.ReadCloser, error) {
@@ -282,130 +669,227 @@ func (c Client) GetLink(ctx context.Context, link string) (io.ReadCloser, error)
 	req.Header.Set("X-ELS-APIKey", c.apiKey)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, err
+		defer resp.Body.Close()
+		return nil, extractError(resp)
 	}
 	return resp.Body, nil
 }
 
-func ParseAbstract(r io.Reader) (string, error) {
-	data, err := io.ReadAll(r)
-	if err != nil {
-		return "", err
-	}
-	buf := bytes.NewReader(data)
+// Views of the Abstract Retrieval API. FULL carries the bibliography,
+// which META_ABS lacks.
+const (
+	abstractViewFull    = "FULL"
+	abstractViewMetaAbs = "META_ABS"
+)
 
-	rgx := regexp.MustCompile("<section +.*id=\"abstractSection\".*>")
-	index := rgx.FindReaderIndex(buf)
-	if index == nil {
-		return "", fmt.Errorf("abstractSection not found within input reader")
-	}
+// openStrings decodes the values Elsevier encodes either as a string, as
+// an object holding it in "$" or as a list of those.
+type openStrings []string
 
-	buf.Seek(int64(index[0]), io.SeekStart)
+func (s *openStrings) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		*s = nil
+		return nil
+	case data[0] == '[':
+		var raw []json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		var all openStrings
+		for _, v := range raw {
+			var item openStrings
+			if err := item.UnmarshalJSON(v); err != nil {
+				return err
+			}
+			all = append(all, item...)
+		}
+		*s = all
+		return nil
+	case data[0] == '{':
+		var v openValue
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*s = openStrings{v.Value}
+		return nil
+	default:
+		var v string
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*s = openStrings{v}
+		return nil
+	}
+}
 
-	d := xml.NewDecoder(buf)
-	d.Strict = false
-	d.AutoClose = xml.HTMLAutoClose
-	d.Entity = xml.HTMLEntity
+// openDescription is the dc:description of an abstract retrieval
+// response: plain text, or a structured abstract made of paragraphs.
+type openDescription struct {
+	Paragraphs openStrings
+}
 
-	max := 50
-	for i := 0; i < max; i++ {
-		t, err := d.Token()
-		if err != nil {
-			return "", fmt.Errorf("find abstract char data: %w", err)
+func (d *openDescription) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var v struct {
+			Abstract struct {
+				Paragraphs openStrings `json:"ce:para"`
+			} `json:"abstract"`
 		}
-		if se, ok := t.(xml.StartElement); ok && se.Name.Local == "p" {
-			next, err := d.Token()
-			if err != nil {
-				panic(err)
-			}
-			cd, ok := next.(xml.CharData)
-			if !ok {
-				return "", fmt.Errorf("expected to find char data after paragraph, found %T", next)
-			}
-			return string(cd), nil
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
 		}
+		d.Paragraphs = v.Abstract.Paragraphs
+		return nil
 	}
-	return "", fmt.Errorf("could not find abstract char data within abstract section")
+	return d.Paragraphs.UnmarshalJSON(data)
 }
 
-func (c Client) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
-	body, err := c.GetLink(ctx, p.Values[KeyLinkAbstract])
+type openAbstractResponse struct {
+	Response struct {
+		Coredata struct {
+			Description openDescription `json:"dc:description"`
+		} `json:"coredata"`
+		AuthKeywords struct {
+			Keywords openStrings `json:"author-keyword"`
+		} `json:"authkeywords"`
+		IdxTerms struct {
+			MainTerms openStrings `json:"mainterm"`
+		} `json:"idxterms"`
+		Item struct {
+			Bibrecord struct {
+				Tail struct {
+					Bibliography struct {
+						RefCount string `json:"@refcount"`
+					} `json:"bibliography"`
+				} `json:"tail"`
+			} `json:"bibrecord"`
+		} `json:"item"`
+	} `json:"abstracts-retrieval-response"`
+}
+
+// AbstractRecord is what the Abstract Retrieval API knows about the
+// abstract of a publication.
+type AbstractRecord struct {
+	Paragraphs     []string
+	AuthorKeywords []string
+	IndexTerms     []string
+	// ReferenceCount is -1 when the bibliography is not available to the
+	// key.
+	ReferenceCount int
+}
+
+// Text returns the paragraphs of the abstract, one per line.
+func (r AbstractRecord) Text() string {
+	return strings.Join(r.Paragraphs, "\n")
+}
+
+func (c Client) getAbstractView(ctx context.Context, eid, view string) (AbstractRecord, error) {
+	u, err := url.Parse(fmt.Sprintf(c.abstractEndpoint, url.PathEscape(eid)))
 	if err != nil {
-		return lit.Abstract{}, err
+		panic(err)
 	}
-	defer body.Close()
+	q := u.Query()
+	q.Set("view", view)
+	u.RawQuery = q.Encode()
 
-	text, err := ParseAbstract(body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return lit.Abstract{}, err
+		panic(err)
 	}
-	return lit.Abstract{
-		Text: text,
-	}, nil
-}
+	req.Header.Set("X-ELS-APIKey", c.apiKey)
+	req.Header.Set("Accept", "application/json")
 
-func (c Client) GetName() string {
-	return "Scopus by ELSEVIER"
-}
+	resp, err := c.do(req)
+	if err != nil {
+		return AbstractRecord{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return AbstractRecord{}, extractError(resp)
+	}
 
-func getStringPtr(p lit.Publication, key string) *string {
-	val, ok := p.Values[key]
-	if !ok {
-		return nil
+	var p openAbstractResponse
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return AbstractRecord{}, fmt.Errorf("decode abstract of %s: %w", eid, lit.Malformed(err))
 	}
-	if len(val) == 0 {
-		return nil
+	r := p.Response
+	record := AbstractRecord{
+		AuthorKeywords: r.AuthKeywords.Keywords,
+		IndexTerms:     r.IdxTerms.MainTerms,
+		ReferenceCount: -1,
+	}
+	for _, v := range r.Coredata.Description.Paragraphs {
+		if v = strings.TrimSpace(v); v != "" {
+			record.Paragraphs = append(record.Paragraphs, v)
+		}
 	}
-	return &val
+	if n, err := strconv.Atoi(r.Item.Bibrecord.Tail.Bibliography.RefCount); err == nil {
+		record.ReferenceCount = n
+	}
+	return record, nil
 }
 
-func makeEntry(p lit.Publication) bibtex.Entry {
-	doi := getStringPtr(p, KeyDOI)
-	issn := getStringPtr(p, KeyIssn)
-	url := getStringPtr(p, KeyLinkAbstract)
-
-	var abstract *string
-	if abs := p.Abstract; abs != nil {
-		abstract = &(abs.Text)
+// GetAbstractRecord looks p up through the Abstract Retrieval API,
+// falling back to the META_ABS view, which has no bibliography, when the
+// key is not entitled to the FULL one.
+func (c Client) GetAbstractRecord(ctx context.Context, p lit.Publication) (AbstractRecord, error) {
+	eid := p.Values[KeyEid]
+	if eid == "" {
+		return AbstractRecord{}, fmt.Errorf("publication %q has no eid", p.Title)
 	}
-
-	var keywords *string
-	if k := p.Keywords; k != nil {
-		text := k.Text()
-		keywords = &text
+	record, err := c.getAbstractView(ctx, eid, abstractViewFull)
+	if !errors.Is(err, lit.ErrNotEntitled) {
+		return record, err
 	}
+	return c.getAbstractView(ctx, eid, abstractViewMetaAbs)
+}
 
-	var reason *string
-	if rev := p.Review; rev != nil && !rev.IsAccepted {
-		reason = &(rev.RejectReason)
+func (c Client) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	if p.Abstract != nil {
+		return *p.Abstract, nil
 	}
-
-	return bibtex.Entry{
-		Title:        p.Title,
-		Author:       p.Creator,
-		Year:         p.CoverDate.Year(),
-		DOI:          doi,
-		Issn:         issn,
-		Url:          url,
-		Abstract:     abstract,
-		Keywords:     keywords,
-		RejectReason: reason,
+	record, err := c.GetAbstractRecord(ctx, p)
+	if err != nil {
+		return lit.Abstract{}, err
 	}
+	return lit.Abstract{
+		Text: record.Text(),
+	}, nil
+}
+
+// HTTPClient returns the client used to talk to the API, see
+// lit.HTTPLibrary.
+func (c Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+func (c Client) GetName() string {
+	return "Scopus by ELSEVIER"
+}
+
+// NativeID returns the EID of p.
+func (c Client) NativeID(p lit.Publication) string {
+	return p.Values[KeyEid]
 }
 
 func makeArticle(p lit.Publication) bibtex.Reference {
-	pageRange := getStringPtr(p, KeyPageRange)
-	volume := getStringPtr(p, KeyVolume)
+	pageRange := p.Value(KeyPageRange)
+	volume := p.Value(KeyVolume)
+	number := p.Value(lit.KeyIssue)
 
 	return bibtex.Article{
-		Entry:     makeEntry(p),
+		Entry:     p.BibTeXEntry(),
 		Journal:   p.Values[KeyPublicationName],
 		Volume:    volume,
+		Number:    number,
 		PageRange: pageRange,
 	}
 }
@@ -413,50 +897,45 @@ func makeArticle(p lit.Publication) bibtex.Reference {
 func makeMisc(p lit.Publication) bibtex.Reference {
 	note := fmt.Sprintf("%q", p.Values)
 	return bibtex.Misc{
-		Entry: makeEntry(p),
+		Entry: p.BibTeXEntry(),
 		Note:  &note,
 	}
 }
 
 func makeInProceedings(p lit.Publication) bibtex.Reference {
-	// TODO: add publisher
-	// var publisher *string
-
-	pageRange := getStringPtr(p, KeyPageRange)
-	address := getStringPtr(p, KeyAffiliation)
+	publisher := p.Value(lit.KeyPublisher)
+	pageRange := p.Value(KeyPageRange)
+	address := p.Value(KeyAffiliation)
 
 	return bibtex.InProceedings{
-		Entry:     makeEntry(p),
+		Entry:     p.BibTeXEntry(),
 		BookTitle: p.Values[KeyPublicationName],
+		Publisher: publisher,
 		Address:   address,
 		PageRange: pageRange,
 	}
 }
 
 func makeInCollection(p lit.Publication) bibtex.Reference {
-	// TODO: add publisher
-	// var publisher *string
-
-	pageRange := getStringPtr(p, KeyPageRange)
-	address := getStringPtr(p, KeyAffiliation)
+	pageRange := p.Value(KeyPageRange)
+	address := p.Value(KeyAffiliation)
 
 	return bibtex.InCollection{
-		Entry:     makeEntry(p),
+		Entry:     p.BibTeXEntry(),
 		BookTitle: p.Values[KeyPublicationName],
+		Publisher: p.Values[lit.KeyPublisher],
 		Address:   address,
 		PageRange: pageRange,
 	}
 }
 
 func makeBook(p lit.Publication) bibtex.Reference {
-	// TODO: add publisher
-	// var publisher *string
-
-	address := getStringPtr(p, KeyAffiliation)
+	address := p.Value(KeyAffiliation)
 
 	return bibtex.Book{
-		Entry:   makeEntry(p),
-		Address: address,
+		Entry:     p.BibTeXEntry(),
+		Publisher: p.Values[lit.KeyPublisher],
+		Address:   address,
 	}
 }
 
@@ -509,7 +988,12 @@ func NewClient(apiKey string) Client {
 	}
 
 	return Client{
-		apiKey:     apiKey,
-		httpClient: client,
+		apiKey:           apiKey,
+		endpoint:         searchEndpoint,
+		abstractEndpoint: abstractEndpoint,
+		view:             ViewComplete,
+		httpClient:       client,
+		standardOnly:     new(int32),
+		quota:            new(lit.QuotaTracker),
 	}
 }
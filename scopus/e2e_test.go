@@ -0,0 +1,281 @@
+package scopus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/littest"
+	"github.com/jecoz/lit/scopus"
+	"github.com/jecoz/lit/scopus/scopustest"
+)
+
+// corpus returns n documents, alternating between FPGAs and GPUs, one
+// year after the other from 2000.
+func corpus(n int) []scopustest.Document {
+	docs := make([]scopustest.Document, n)
+	for i := range docs {
+		topic := "FPGA"
+		if i%2 == 1 {
+			topic = "GPU"
+		}
+		docs[i] = scopustest.Document{
+			EID:             fmt.Sprintf("2-s2.0-85%09d", i),
+			Title:           fmt.Sprintf("Accelerating neural networks on %s, part %d", topic, i),
+			Authors:         []scopustest.Author{{ID: "6506362916", Surname: "Nurvitadhi", GivenName: "Eriko"}},
+			PublicationName: "FPGA 2017 - Proceedings of the 2017 ACM/SIGDA International Symposium on Field-Programmable Gate Arrays",
+			AggregationType: "Conference Proceeding",
+			Subtype:         "cp",
+			CoverDate:       time.Date(2000+i%20, time.February, 22, 0, 0, 0, 0, time.UTC),
+			DOI:             fmt.Sprintf("10.1145/3020078.%d", i),
+			CitedBy:         i,
+			Abstract:        "Deep neural networks rely on dense matrix multiplication.\nThis paper evaluates them on " + topic + "s.",
+			Keywords:        []string{"Accelerator", "Deep learning", topic},
+			IndexTerms:      []string{"Neural networks"},
+			References:      30 + i,
+		}
+	}
+	return docs
+}
+
+func newClient(t *testing.T, srv *scopustest.Server) scopus.Client {
+	t.Cleanup(srv.Close)
+	c := scopus.NewClient("secret")
+	c.HTTPClient().Transport = srv.Transport()
+	return c
+}
+
+func fastRetries(t *testing.T) {
+	policy := lit.DefaultRetryPolicy
+	lit.DefaultRetryPolicy = lit.RetryPolicy{Retries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond * 4}
+	t.Cleanup(func() { lit.DefaultRetryPolicy = policy })
+}
+
+func TestSearchPages(t *testing.T) {
+	fastRetries(t)
+	srv := scopustest.New(corpus(60)...)
+	c := newClient(t, srv)
+	ctx := context.Background()
+	q := "TITLE-ABS-KEY(fpga AND \"neural networks\")"
+
+	n, err := c.GetMaxLiterature(ctx, lit.Request{Query: q})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 30 {
+		t.Fatalf("have %d results, want 30", n)
+	}
+
+	// A burst of requests is throttled, then retried.
+	srv.Throttle(2)
+	blobChan := lit.NewBlobChan(n, 0)
+	go lit.GetLiterature(ctx, blobChan, c, lit.Request{Query: q})
+	seen := make(map[string]bool)
+	for h := range blobChan.Recv() {
+		p, err := c.ParsePublication(h.Blob)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Abstract == nil || p.Values[scopus.KeyAuthors] == "" {
+			t.Errorf("%s: not a COMPLETE entry", p.Title)
+		}
+		seen[p.Values[scopus.KeyEid]] = true
+	}
+	if err := blobChan.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != n {
+		t.Errorf("have %d publications, want %d", len(seen), n)
+	}
+
+	n, err = c.GetMaxLiterature(ctx, lit.Request{Query: c.SliceQuery(q, 2004, 2009)})
+	if err != nil || n != 9 {
+		t.Errorf("have %d results in 2004-2009, %v, want 9", n, err)
+	}
+}
+
+func TestSearchCursor(t *testing.T) {
+	c := newClient(t, scopustest.New(corpus(60)...))
+	req := lit.Request{Query: "gpu", PerPage: 25, Cursor: lit.CursorStart}
+	n := 0
+	for i := 0; req.Cursor != ""; i++ {
+		if i == 10 {
+			t.Fatal("cursor never ends")
+		}
+		resp, err := c.GetLiterature(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n += resp.Len()
+		req.Cursor = resp.Next
+	}
+	if n != 30 {
+		t.Errorf("have %d results, want 30", n)
+	}
+}
+
+func TestSearchEmpty(t *testing.T) {
+	c := newClient(t, scopustest.New(corpus(10)...))
+	ctx := context.Background()
+	req := lit.Request{Query: "TITLE(asic)", PerPage: 25}
+	if n, err := c.GetMaxLiterature(ctx, req); err != nil || n != 0 {
+		t.Fatalf("have %d results, %v", n, err)
+	}
+	resp, err := c.GetLiterature(ctx, req)
+	if err != nil || !resp.IsEmpty() {
+		t.Errorf("have %d blobs, %v", resp.Len(), err)
+	}
+}
+
+func TestStandardOnly(t *testing.T) {
+	srv := scopustest.New(corpus(4)...)
+	srv.SetStandardOnly(true)
+	c := newClient(t, srv)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		resp, err := c.GetLiterature(ctx, lit.Request{Query: "fpga", PerPage: 25})
+		if err != nil || resp.Len() != 2 {
+			t.Fatalf("have %d blobs, %v", resp.Len(), err)
+		}
+	}
+	// The COMPLETE view is only tried once.
+	var views []string
+	for _, u := range srv.Requests() {
+		views = append(views, u.Query().Get("view"))
+	}
+	if fmt.Sprint(views) != "[COMPLETE STANDARD STANDARD]" {
+		t.Errorf("unexpected views %v", views)
+	}
+
+	p := lit.Publication{Title: "t", Values: map[string]string{scopus.KeyEid: "2-s2.0-85000000000"}}
+	record, err := c.GetAbstractRecord(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(record.Paragraphs) != 2 || len(record.AuthorKeywords) != 3 || record.ReferenceCount != -1 {
+		t.Errorf("unexpected META_ABS record %+v", record)
+	}
+}
+
+func TestGetAbstractRecordFull(t *testing.T) {
+	c := newClient(t, scopustest.New(corpus(4)...))
+	p := lit.Publication{Title: "t", Values: map[string]string{scopus.KeyEid: "2-s2.0-85000000003"}}
+	record, err := c.GetAbstractRecord(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.ReferenceCount != 33 || len(record.IndexTerms) != 1 || record.Text() != "Deep neural networks rely on dense matrix multiplication.\nThis paper evaluates them on GPUs." {
+		t.Errorf("unexpected record %+v", record)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	req := lit.Request{Query: "fpga", PerPage: 25}
+	tt := []struct {
+		name  string
+		setup func(*scopustest.Server)
+		do    func(scopus.Client) error
+		want  error
+	}{
+		{
+			name:  "wrong key",
+			setup: func(s *scopustest.Server) { s.SetKey("another") },
+			want:  lit.ErrUnauthorized,
+		},
+		{
+			name:  "quota exceeded",
+			setup: func(s *scopustest.Server) { s.SetQuota(20000, 0, time.Now().Add(time.Hour)) },
+			want:  lit.ErrQuotaExceeded,
+		},
+		{
+			name:  "throttled",
+			setup: func(s *scopustest.Server) { s.Throttle(1) },
+			want:  lit.ErrRateLimited,
+		},
+		{
+			name:  "bad query",
+			setup: func(s *scopustest.Server) {},
+			do: func(c scopus.Client) error {
+				_, err := c.GetMaxLiterature(ctx, lit.Request{Query: "fpga AND (gpu"})
+				var se *lit.StatusError
+				if errors.As(err, &se) && se.StatusCode == http.StatusBadRequest {
+					return nil
+				}
+				return err
+			},
+		},
+		{
+			name:  "unknown publication",
+			setup: func(s *scopustest.Server) {},
+			do: func(c scopus.Client) error {
+				_, err := c.GetAbstract(ctx, lit.Publication{Values: map[string]string{scopus.KeyEid: "2-s2.0-0"}})
+				return err
+			},
+			want: lit.ErrNotFound,
+		},
+	}
+	for _, v := range tt {
+		srv := scopustest.New(corpus(4)...)
+		v.setup(srv)
+		c := newClient(t, srv)
+		do := v.do
+		if do == nil {
+			do = func(c scopus.Client) error {
+				_, err := c.GetLiterature(ctx, req)
+				return err
+			}
+		}
+		if err := do(c); !errors.Is(err, v.want) {
+			t.Errorf("%s: have %v, want %v", v.name, err, v.want)
+		}
+	}
+}
+
+func TestQuotaHeaders(t *testing.T) {
+	srv := scopustest.New(corpus(4)...)
+	reset := time.Date(2021, time.May, 10, 9, 53, 11, 0, time.UTC)
+	srv.SetQuota(20000, 2, reset)
+	c := newClient(t, srv)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetMaxLiterature(ctx, lit.Request{Query: "fpga"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	q, ok := c.Quota()
+	if !ok || q.Limit != 20000 || q.Remaining != 0 || !q.Reset.Equal(reset) {
+		t.Errorf("unexpected quota %+v", q)
+	}
+	if _, err := c.GetMaxLiterature(ctx, lit.Request{Query: "fpga"}); !errors.Is(err, lit.ErrQuotaExceeded) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestFacets(t *testing.T) {
+	c := newClient(t, scopustest.New(corpus(60)...))
+	ctx := context.Background()
+	facets, err := c.Facets(ctx, lit.Request{Query: "gpu"}, lit.FacetYear)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// GPU documents come out every other year from 2001.
+	if len(facets) != 10 || facets[0] != (lit.Facet{Value: "2001", Count: 3}) || facets[9].Value != "2019" {
+		t.Errorf("unexpected facets %v", facets)
+	}
+	if _, err := c.Facets(ctx, lit.Request{Query: "gpu"}, "language"); !errors.Is(err, lit.ErrUnsupported) {
+		t.Errorf("have %v, want %v", err, lit.ErrUnsupported)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	fastRetries(t)
+	c := newClient(t, scopustest.New(corpus(60)...))
+	littest.RunConformance(t, c, littest.Fixture{Query: "TITLE-ABS-KEY(gpu)", PerPage: 7, Max: 30})
+}
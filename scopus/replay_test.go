@@ -0,0 +1,70 @@
+package scopus
+
+import (
+	"context"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/httpcache"
+)
+
+var record = flag.Bool("record", false, "record the cassettes of TestReplay from the Scopus API, with the key in SCOPUS_API_KEY")
+
+// newReplayClient returns a client talking to the actual endpoints
+// through the cassettes in testdata, which need no key, or recording them
+// when -record is set.
+func newReplayClient(t *testing.T) Client {
+	mode, key := httpcache.ModeReplay, ""
+	if *record {
+		if key = os.Getenv("SCOPUS_API_KEY"); key == "" {
+			t.Fatal("recording requires SCOPUS_API_KEY")
+		}
+		mode = httpcache.ModeRecord
+	}
+	c := NewClient(key)
+	h := c.HTTPClient()
+	h.Transport = httpcache.New("testdata/cassettes", mode, 0, h.Transport)
+	return c
+}
+
+func TestReplay(t *testing.T) {
+	c := newReplayClient(t)
+	ctx := context.Background()
+	req := lit.Request{Query: `TITLE("fpga accelerator" AND "neural network")`, PerPage: 2}
+
+	n, err := c.GetMaxLiterature(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("have %d results, want 2", n)
+	}
+
+	req.MaxResults = n
+	resp, err := c.GetLiterature(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 2 {
+		t.Fatalf("have %d blobs, want 2", resp.Len())
+	}
+	p, err := c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Values[KeyEid] != "2-s2.0-85016025377" || p.Abstract == nil {
+		t.Fatalf("unexpected publication %+v", p)
+	}
+
+	// The key is not entitled to the FULL view: the record comes from
+	// the META_ABS one.
+	record, err := c.GetAbstractRecord(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(record.Paragraphs) != 2 || len(record.AuthorKeywords) != 3 || record.ReferenceCount != -1 {
+		t.Errorf("unexpected record %+v", record)
+	}
+}
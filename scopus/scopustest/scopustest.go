@@ -0,0 +1,596 @@
+// Package scopustest provides a fake Scopus API, serving the Search and
+// Abstract Retrieval endpoints out of a corpus of documents, to test
+// scopus.Client end to end without a key:
+//
+//	srv := scopustest.New(docs...)
+//	defer srv.Close()
+//	c := scopus.NewClient("key")
+//	c.HTTPClient().Transport = srv.Transport()
+//
+// Queries are evaluated with the query package, which understands the
+// boolean operators and the usual field restrictions of Scopus, e.g.
+// TITLE-ABS-KEY(fpga AND "neural network"). PUBYEAR clauses are
+// understood when joined to the rest of the query with AND, as
+// scopus.Client.SliceQuery does. Results come in corpus order.
+package scopustest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jecoz/lit/query"
+)
+
+const (
+	searchPath   = "/content/search/scopus"
+	abstractPath = "/content/abstract/eid/"
+
+	// offsetLimit is the maximum start+count accepted: results past it
+	// can only be reached through cursors.
+	offsetLimit = 5000
+	// maxCountStandard and maxCountComplete are the maximum counts
+	// accepted by the STANDARD and COMPLETE views.
+	maxCountStandard = 200
+	maxCountComplete = 25
+)
+
+// Author is an author of a Document.
+type Author struct {
+	ID        string
+	Surname   string
+	GivenName string
+}
+
+// Name returns the name of a in the "Surname G." form used by Scopus.
+func (a Author) Name() string {
+	if a.GivenName == "" {
+		return a.Surname
+	}
+	return a.Surname + " " + a.GivenName[:1] + "."
+}
+
+// Document is a publication in the corpus of a Server.
+type Document struct {
+	EID             string
+	Title           string
+	Authors         []Author
+	PublicationName string
+	// AggregationType is e.g. "Journal" or "Conference Proceeding",
+	// Subtype "ar" or "cp".
+	AggregationType string
+	Subtype         string
+	CoverDate       time.Time
+	DOI             string
+	CitedBy         int
+	// Abstract holds one paragraph per line.
+	Abstract   string
+	Keywords   []string
+	IndexTerms []string
+	// References is the size of the bibliography, shown by the FULL
+	// view of the Abstract Retrieval API.
+	References int
+}
+
+// Text implements query.Document, understanding the Scopus field names
+// TITLE, ABS, KEY, AUTHKEY, TITLE-ABS, TITLE-ABS-KEY, ALL, AUTH, AUTHOR,
+// SRCTITLE and DOI.
+func (d Document) Text(field string) string {
+	switch field {
+	case "", "TITLE-ABS-KEY", "ALL":
+		return strings.Join([]string{d.Title, d.Abstract, strings.Join(d.Keywords, " ")}, " ")
+	case "TITLE-ABS":
+		return d.Title + " " + d.Abstract
+	case "TITLE":
+		return d.Title
+	case "ABS":
+		return d.Abstract
+	case "KEY", "AUTHKEY":
+		return strings.Join(d.Keywords, " ")
+	case "AUTH", "AUTHOR":
+		names := make([]string, len(d.Authors))
+		for i, v := range d.Authors {
+			names[i] = v.GivenName + " " + v.Surname
+		}
+		return strings.Join(names, " ")
+	case "SRCTITLE":
+		return d.PublicationName
+	case "DOI":
+		return d.DOI
+	default:
+		return ""
+	}
+}
+
+func (d Document) creator() string {
+	if len(d.Authors) == 0 {
+		return ""
+	}
+	return d.Authors[0].Name()
+}
+
+func (d Document) scopusID() string {
+	return strings.TrimPrefix(d.EID, "2-s2.0-")
+}
+
+// failure is a response to give instead of the one requested.
+type failure struct {
+	code   int
+	status string
+}
+
+// Server is a fake Scopus API. Its methods configure it, and are safe to
+// call while it serves requests.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	corpus       []Document
+	key          string
+	standardOnly bool
+	limit        int
+	remaining    int
+	reset        time.Time
+	failures     []failure
+	requests     []*url.URL
+}
+
+// New starts a Server serving corpus. Close it when done.
+func New(corpus ...Document) *Server {
+	s := &Server{corpus: corpus}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Transport returns a transport sending the requests to the Scopus API,
+// or any other host, to s.
+func (s *Server) Transport() http.RoundTripper {
+	return redirect{base: s.Client().Transport, host: s.Listener.Addr().String()}
+}
+
+type redirect struct {
+	base http.RoundTripper
+	host string
+}
+
+func (t redirect) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = "http"
+	r.URL.Host = t.host
+	r.Host = ""
+	return t.base.RoundTrip(r)
+}
+
+// SetKey makes s refuse the requests not carrying key. By default, any
+// key is accepted.
+func (s *Server) SetKey(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key = key
+}
+
+// SetStandardOnly tells whether the key is only entitled to the STANDARD
+// view of the Search API and to the META and META_ABS views of the
+// Abstract Retrieval API.
+func (s *Server) SetStandardOnly(standardOnly bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.standardOnly = standardOnly
+}
+
+// SetQuota makes s report a quota of limit requests a week, remaining of
+// which are left until reset, through the X-RateLimit headers. Once none
+// are left, requests fail with QUOTA_EXCEEDED. By default, there is no
+// quota.
+func (s *Server) SetQuota(limit, remaining int, reset time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit, s.remaining, s.reset = limit, remaining, reset
+}
+
+// Remaining returns the requests left to the quota.
+func (s *Server) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.remaining
+}
+
+// Fail makes the next n requests fail with code, detailed by status in
+// the X-Els-Status header, e.g. "RESOURCE_NOT_FOUND". Failures are not
+// counted against the quota.
+func (s *Server) Fail(n, code int, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, failure{code: code, status: status})
+	}
+}
+
+// Throttle makes the next n requests fail with 429 Too Many Requests, as
+// a burst exceeding the rate limit does. The quota is left untouched.
+func (s *Server) Throttle(n int) {
+	s.Fail(n, http.StatusTooManyRequests, "")
+}
+
+// Requests returns the URLs requested so far, failed requests included.
+func (s *Server) Requests() []*url.URL {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*url.URL(nil), s.requests...)
+}
+
+// serviceError writes an error in the format of the Scopus API.
+func serviceError(w http.ResponseWriter, code int, status, text string) {
+	if status != "" {
+		w.Header().Set("X-Els-Status", strings.TrimSpace(status+" - "+text))
+	}
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"service-error": map[string]interface{}{
+			"status": map[string]string{"statusCode": status, "statusText": text},
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.URL)
+	corpus, standardOnly := s.corpus, s.standardOnly
+
+	key := r.Header.Get("X-ELS-APIKey")
+	if key == "" {
+		key = r.URL.Query().Get("apiKey")
+	}
+	if s.key != "" && key != s.key {
+		s.mu.Unlock()
+		serviceError(w, http.StatusUnauthorized, "INVALID_API_KEY", "Invalid API Key")
+		return
+	}
+
+	if len(s.failures) > 0 {
+		f := s.failures[0]
+		s.failures = s.failures[1:]
+		s.mu.Unlock()
+		serviceError(w, f.code, f.status, http.StatusText(f.code))
+		return
+	}
+	if s.limit > 0 {
+		exceeded := s.remaining == 0
+		if !exceeded {
+			s.remaining--
+		}
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(s.limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(s.remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(s.reset.Unix(), 10))
+		if exceeded {
+			s.mu.Unlock()
+			serviceError(w, http.StatusTooManyRequests, "QUOTA_EXCEEDED", "Quota Exceeded")
+			return
+		}
+	}
+	s.mu.Unlock()
+
+	switch {
+	case r.URL.Path == searchPath:
+		search(w, r, corpus, standardOnly)
+	case strings.HasPrefix(r.URL.Path, abstractPath):
+		abstract(w, r, corpus, standardOnly)
+	default:
+		serviceError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "The resource specified cannot be found.")
+	}
+}
+
+var pubyear = regexp.MustCompile(`\s+AND\s+PUBYEAR\s*(>|<|=|IS)\s*(\d{4})`)
+
+// parseQuery returns the expression of q, along with a filter applying
+// its PUBYEAR clauses.
+func parseQuery(q string) (query.Expr, func(Document) bool, error) {
+	type clause struct {
+		op   string
+		year int
+	}
+	var clauses []clause
+	for _, m := range pubyear.FindAllStringSubmatch(q, -1) {
+		year, _ := strconv.Atoi(m[2])
+		clauses = append(clauses, clause{op: m[1], year: year})
+	}
+	expr, err := query.Parse(pubyear.ReplaceAllString(q, ""))
+	if err != nil {
+		return nil, nil, err
+	}
+	return expr, func(d Document) bool {
+		year := d.CoverDate.Year()
+		for _, v := range clauses {
+			switch {
+			case v.op == ">" && year <= v.year,
+				v.op == "<" && year >= v.year,
+				(v.op == "=" || v.op == "IS") && year != v.year:
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("offset:%d", offset)))
+}
+
+func decodeCursor(c string) (int, bool) {
+	if c == "*" {
+		return 0, true
+	}
+	b, err := base64.RawURLEncoding.DecodeString(c)
+	if err != nil {
+		return 0, false
+	}
+	var offset int
+	if _, err := fmt.Sscanf(string(b), "offset:%d", &offset); err != nil {
+		return 0, false
+	}
+	return offset, true
+}
+
+func search(w http.ResponseWriter, r *http.Request, corpus []Document, standardOnly bool) {
+	q := r.URL.Query()
+	view := q.Get("view")
+	maxCount := maxCountStandard
+	switch view {
+	case "", "STANDARD":
+		view = "STANDARD"
+	case "COMPLETE":
+		if standardOnly {
+			serviceError(w, http.StatusUnauthorized, "AUTHORIZATION_ERROR", "The requestor is not authorized to access the requested view or fields of the resource")
+			return
+		}
+		maxCount = maxCountComplete
+	default:
+		serviceError(w, http.StatusBadRequest, "INVALID_INPUT", "View parameter specified in request is not valid")
+		return
+	}
+
+	expr, filter, err := parseQuery(q.Get("query"))
+	if err != nil {
+		serviceError(w, http.StatusBadRequest, "INVALID_INPUT", "Error translating query: "+err.Error())
+		return
+	}
+	count := maxCountComplete
+	if v := q.Get("count"); v != "" {
+		if count, err = strconv.Atoi(v); err != nil || count < 0 {
+			serviceError(w, http.StatusBadRequest, "INVALID_INPUT", "Invalid count")
+			return
+		}
+	}
+	if count > maxCount {
+		serviceError(w, http.StatusBadRequest, "INVALID_INPUT", "Exceeds the maximum number allowed for the service level")
+		return
+	}
+
+	cursor := q.Get("cursor")
+	start := 0
+	if cursor != "" {
+		var ok bool
+		if start, ok = decodeCursor(cursor); !ok {
+			serviceError(w, http.StatusBadRequest, "INVALID_INPUT", "Invalid cursor")
+			return
+		}
+	} else if v := q.Get("start"); v != "" {
+		if start, err = strconv.Atoi(v); err != nil || start < 0 {
+			serviceError(w, http.StatusBadRequest, "INVALID_INPUT", "Invalid start")
+			return
+		}
+		if start+count > offsetLimit {
+			serviceError(w, http.StatusBadRequest, "INVALID_INPUT", "Exceeds the number of search results")
+			return
+		}
+	}
+
+	var matches []Document
+	for _, v := range corpus {
+		if expr.Match(v) && filter(v) {
+			matches = append(matches, v)
+		}
+	}
+	from, to := start, start+count
+	if from > len(matches) {
+		from = len(matches)
+	}
+	if to > len(matches) {
+		to = len(matches)
+	}
+
+	entries := make([]interface{}, 0, to-from)
+	for _, v := range matches[from:to] {
+		entries = append(entries, searchEntry(v, view == "COMPLETE"))
+	}
+	if len(entries) == 0 {
+		entries = append(entries, map[string]string{"@_fa": "true", "error": "Result set was empty"})
+	}
+	results := map[string]interface{}{
+		"opensearch:totalResults": strconv.Itoa(len(matches)),
+		"opensearch:startIndex":   strconv.Itoa(start),
+		"opensearch:itemsPerPage": strconv.Itoa(to - from),
+		"opensearch:Query": map[string]string{
+			"@role":        "request",
+			"@searchTerms": q.Get("query"),
+			"@startPage":   strconv.Itoa(start),
+		},
+		"entry": entries,
+	}
+	if v := q.Get("facets"); v != "" {
+		if !strings.HasPrefix(v, "pubyear") {
+			serviceError(w, http.StatusBadRequest, "INVALID_INPUT", "Invalid facets")
+			return
+		}
+		results["facet"] = yearFacet(matches)
+	}
+	if cursor != "" {
+		c := map[string]string{"@current": cursor}
+		if to < len(matches) {
+			c["@next"] = encodeCursor(to)
+		}
+		results["cursor"] = c
+	}
+	writeJSON(w, map[string]interface{}{"search-results": results})
+}
+
+// yearFacet breaks docs down by year of publication, most frequent years
+// first, as the pubyear facet does.
+func yearFacet(docs []Document) map[string]interface{} {
+	counts := make(map[int]int)
+	for _, v := range docs {
+		counts[v.CoverDate.Year()]++
+	}
+	years := make([]int, 0, len(counts))
+	for k := range counts {
+		years = append(years, k)
+	}
+	sort.Slice(years, func(i, j int) bool {
+		if counts[years[i]] != counts[years[j]] {
+			return counts[years[i]] > counts[years[j]]
+		}
+		return years[i] > years[j]
+	})
+	categories := make([]map[string]string, len(years))
+	for i, v := range years {
+		year := strconv.Itoa(v)
+		categories[i] = map[string]string{"name": year, "value": year, "label": year, "hitCount": strconv.Itoa(counts[v])}
+	}
+	return map[string]interface{}{"name": "pubyear", "attribute": "pubyear", "category": categories}
+}
+
+func searchEntry(d Document, complete bool) map[string]interface{} {
+	id := d.scopusID()
+	e := map[string]interface{}{
+		"@_fa": "true",
+		"link": []map[string]string{
+			{"@_fa": "true", "@ref": "self", "@href": "https://api.elsevier.com/content/abstract/scopus_id/" + id},
+			{"@_fa": "true", "@ref": "scopus", "@href": "https://www.scopus.com/inward/record.uri?partnerID=HzOxMe3b&scp=" + id + "&origin=inward"},
+		},
+		"dc:identifier":         "SCOPUS_ID:" + id,
+		"eid":                   d.EID,
+		"dc:title":              d.Title,
+		"dc:creator":            d.creator(),
+		"prism:publicationName": d.PublicationName,
+		"prism:coverDate":       d.CoverDate.Format("2006-01-02"),
+		"prism:doi":             d.DOI,
+		"citedby-count":         strconv.Itoa(d.CitedBy),
+		"prism:aggregationType": d.AggregationType,
+		"subtype":               d.Subtype,
+	}
+	if !complete {
+		return e
+	}
+	e["dc:description"] = strings.ReplaceAll(d.Abstract, "\n", " ")
+	e["authkeywords"] = strings.Join(d.Keywords, " | ")
+	authors := make([]map[string]string, len(d.Authors))
+	for i, v := range d.Authors {
+		authors[i] = map[string]string{
+			"@_fa":       "true",
+			"@seq":       strconv.Itoa(i + 1),
+			"authid":     v.ID,
+			"authname":   v.Name(),
+			"surname":    v.Surname,
+			"given-name": v.GivenName,
+		}
+	}
+	e["author"] = authors
+	return e
+}
+
+// values renders vs as Scopus does: {"$": v} objects, or an array of
+// them.
+func values(vs []string) interface{} {
+	objs := make([]map[string]string, len(vs))
+	for i, v := range vs {
+		objs[i] = map[string]string{"@_fa": "true", "$": v}
+	}
+	if len(objs) == 1 {
+		return objs[0]
+	}
+	return objs
+}
+
+func abstract(w http.ResponseWriter, r *http.Request, corpus []Document, standardOnly bool) {
+	eid := strings.TrimPrefix(r.URL.Path, abstractPath)
+	view := r.URL.Query().Get("view")
+	switch view {
+	case "", "META", "META_ABS":
+	case "FULL":
+		if standardOnly {
+			serviceError(w, http.StatusUnauthorized, "AUTHORIZATION_ERROR", "The requestor is not authorized to access the requested view or fields of the resource")
+			return
+		}
+	default:
+		serviceError(w, http.StatusBadRequest, "INVALID_INPUT", "View parameter specified in request is not valid")
+		return
+	}
+
+	var (
+		d     Document
+		found bool
+	)
+	for _, v := range corpus {
+		if v.EID == eid {
+			d, found = v, true
+			break
+		}
+	}
+	if !found {
+		serviceError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "The resource specified cannot be found.")
+		return
+	}
+
+	coredata := map[string]interface{}{
+		"eid":           d.EID,
+		"dc:title":      d.Title,
+		"dc:creator":    map[string]interface{}{"author": []map[string]string{{"ce:indexed-name": d.creator()}}},
+		"prism:doi":     d.DOI,
+		"citedby-count": strconv.Itoa(d.CitedBy),
+	}
+	resp := map[string]interface{}{"coredata": coredata}
+	if view != "META" {
+		var paras []string
+		if d.Abstract != "" {
+			paras = strings.Split(d.Abstract, "\n")
+		}
+		var para interface{} = paras
+		if len(paras) == 1 {
+			para = paras[0]
+		}
+		coredata["dc:description"] = map[string]interface{}{
+			"abstract": map[string]interface{}{"@xml:lang": "eng", "ce:para": para},
+		}
+		if len(d.Keywords) > 0 {
+			resp["authkeywords"] = map[string]interface{}{"author-keyword": values(d.Keywords)}
+		}
+		if len(d.IndexTerms) > 0 {
+			resp["idxterms"] = map[string]interface{}{"mainterm": values(d.IndexTerms)}
+		}
+	}
+	if view == "FULL" {
+		resp["item"] = map[string]interface{}{
+			"bibrecord": map[string]interface{}{
+				"tail": map[string]interface{}{
+					"bibliography": map[string]string{"@refcount": strconv.Itoa(d.References)},
+				},
+			},
+		}
+	}
+	writeJSON(w, map[string]interface{}{"abstracts-retrieval-response": resp})
+}
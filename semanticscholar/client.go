@@ -0,0 +1,622 @@
+package semanticscholar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+)
+
+const endpoint = "https://api.semanticscholar.org/graph/v1"
+
+// fields requested for each paper, both when searching and when following
+// citations or references.
+const fields = "paperId,externalIds,url,title,abstract,tldr,venue,publicationVenue,year,publicationDate,journal,publicationTypes,authors,citationCount,referenceCount,fieldsOfStudy,openAccessPdf"
+
+// maxWindow is the maximum offset+limit accepted by the relevance search
+// endpoint.
+const maxWindow = 1000
+
+const (
+	KeyPaperID         = "paper_id"
+	KeyCorpusID        = "corpus_id"
+	KeyArxivID         = "arxiv_id"
+	KeyPMID            = "pmid"
+	KeyLinkAbstract    = lit.KeyLinkAbstract
+	KeyLinkPDF         = "link_pdf"
+	KeyDOI             = lit.KeyDOI
+	KeyIssn            = lit.KeyIssn
+	KeyAuthors         = lit.KeyAuthors
+	KeyPublisher       = lit.KeyPublisher
+	KeyPublicationName = lit.KeyPublicationName
+	KeyVolume          = lit.KeyVolume
+	KeyPageRange       = lit.KeyPageRange
+	KeyPublicationType = "publication_types"
+	KeyVenueType       = "venue_type"
+	KeyFieldsOfStudy   = "fields_of_study"
+	KeyCitedByCount    = "cited_by_count"
+	KeyReferenceCount  = "reference_count"
+)
+
+type Client struct {
+	apiKey     string
+	endpoint   string
+	httpClient *http.Client
+}
+
+func (c Client) DefaultPerPage() int {
+	return 100
+}
+
+func (c Client) newRequest(ctx context.Context, path string, q url.Values) *http.Request {
+	u, err := url.Parse(c.endpoint + path)
+	if err != nil {
+		panic(err)
+	}
+	if q == nil {
+		q = url.Values{}
+	}
+	q.Set("fields", fields)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("x-api-key", c.apiKey)
+	}
+	return req
+}
+
+func (c Client) newSearchRequest(ctx context.Context, src lit.Request) *http.Request {
+	q := url.Values{}
+	q.Set("query", src.Query)
+	q.Set("offset", fmt.Sprintf("%d", src.Page*src.PerPage))
+	q.Set("limit", fmt.Sprintf("%d", src.PerPage))
+	return c.newRequest(ctx, "/paper/search", q)
+}
+
+func extractError(r *http.Response) error {
+	var p struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&p); err == nil {
+		if p.Error != "" {
+			return lit.NewStatusError(r, p.Error)
+		}
+		if p.Message != "" {
+			return lit.NewStatusError(r, p.Message)
+		}
+	}
+	return lit.NewStatusError(r, "")
+}
+
+func (c Client) get(req *http.Request, v interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return extractError(resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+type searchResults struct {
+	Total int               `json:"total"`
+	Data  []json.RawMessage `json:"data"`
+}
+
+func (c Client) GetLiterature(ctx context.Context, req lit.Request) (lit.Response, error) {
+	if req.Page*req.PerPage >= maxWindow {
+		return lit.Response{}, fmt.Errorf("offset %d exceeds the %d results Semantic Scholar search can reach, refine the query", req.Page*req.PerPage, maxWindow)
+	}
+	if req.Page*req.PerPage+req.PerPage > maxWindow {
+		req.PerPage = maxWindow - req.Page*req.PerPage
+	}
+
+	var p searchResults
+	if err := c.get(c.newSearchRequest(ctx, req), &p); err != nil {
+		return lit.Response{}, err
+	}
+	blobs := make([]lit.Blob, len(p.Data))
+	for i, v := range p.Data {
+		blobs[i] = lit.Blob(v)
+	}
+	return lit.Response{
+		Req:   req,
+		Blobs: blobs,
+	}, nil
+}
+
+func (c Client) GetMaxLiterature(ctx context.Context, req lit.Request) (int, error) {
+	req.Page = 0
+	req.PerPage = 1
+	var p searchResults
+	if err := c.get(c.newSearchRequest(ctx, req), &p); err != nil {
+		return 0, err
+	}
+	// Results past the window cannot be downloaded.
+	if p.Total > maxWindow {
+		return maxWindow, nil
+	}
+	return p.Total, nil
+}
+
+// Window returns the number of results of a query the search reaches,
+// see lit.Windowed.
+func (c Client) Window() int {
+	return maxWindow
+}
+
+func (c Client) GetRateLimit() time.Duration {
+	// https://www.semanticscholar.org/product/api: keys are granted one
+	// request per second, anonymous requests share a global pool and
+	// are throttled when it runs dry.
+	return time.Second
+}
+
+func (c Client) ConcurrencyLimit() int {
+	return 1
+}
+
+func (c Client) PrettyPrint(b lit.Blob, dst *bytes.Buffer) error {
+	return json.Indent(dst, []byte(b), "", "\t")
+}
+
+type author struct {
+	AuthorID string `json:"authorId"`
+	Name     string `json:"name"`
+}
+
+type tldr struct {
+	Model string `json:"model"`
+	Text  string `json:"text"`
+}
+
+type venue struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Issn      string `json:"issn"`
+	Publisher string `json:"publisher"`
+}
+
+type journal struct {
+	Name   string `json:"name"`
+	Volume string `json:"volume"`
+	Pages  string `json:"pages"`
+}
+
+type openAccessPdf struct {
+	URL string `json:"url"`
+}
+
+type paper struct {
+	PaperID          string            `json:"paperId"`
+	ExternalIDs      map[string]string `json:"-"`
+	URL              string            `json:"url"`
+	Title            string            `json:"title"`
+	Abstract         string            `json:"abstract"`
+	TLDR             *tldr             `json:"tldr"`
+	Venue            string            `json:"venue"`
+	PublicationVenue *venue            `json:"publicationVenue"`
+	Year             int               `json:"year"`
+	PublicationDate  string            `json:"publicationDate"`
+	Journal          *journal          `json:"journal"`
+	PublicationTypes []string          `json:"publicationTypes"`
+	Authors          []author          `json:"authors"`
+	CitationCount    int               `json:"citationCount"`
+	ReferenceCount   int               `json:"referenceCount"`
+	FieldsOfStudy    []string          `json:"fieldsOfStudy"`
+	OpenAccessPdf    *openAccessPdf    `json:"openAccessPdf"`
+}
+
+func (p *paper) UnmarshalJSON(b []byte) error {
+	// externalIds mixes strings and numbers (CorpusId).
+	type alias paper
+	aux := struct {
+		*alias
+		ExternalIDs map[string]interface{} `json:"externalIds"`
+	}{alias: (*alias)(p)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	p.ExternalIDs = make(map[string]string, len(aux.ExternalIDs))
+	for k, v := range aux.ExternalIDs {
+		switch v := v.(type) {
+		case string:
+			p.ExternalIDs[k] = v
+		case float64:
+			p.ExternalIDs[k] = fmt.Sprintf("%.0f", v)
+		}
+	}
+	return nil
+}
+
+func (p paper) CoverDate() (time.Time, error) {
+	if p.PublicationDate != "" {
+		t, err := time.Parse("2006-01-02", p.PublicationDate)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse publication date: %w", err)
+		}
+		return t, nil
+	}
+	if p.Year != 0 {
+		return time.Date(p.Year, time.January, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Time{}, fmt.Errorf("parse publication date: no date available")
+}
+
+func (p paper) Creator() string {
+	if len(p.Authors) == 0 {
+		return ""
+	}
+	return p.Authors[0].Name
+}
+
+func (p paper) AuthorNames() string {
+	names := make([]string, 0, len(p.Authors))
+	for _, v := range p.Authors {
+		if v.Name != "" {
+			names = append(names, v.Name)
+		}
+	}
+	return strings.Join(names, " and ")
+}
+
+func (p paper) AuthorList() []lit.Author {
+	var authors []lit.Author
+	for _, v := range p.Authors {
+		if v.Name != "" {
+			authors = append(authors, lit.Author{Name: v.Name})
+		}
+	}
+	return authors
+}
+
+// DocumentType maps the publication types of the paper onto the types of
+// lit, reviews taking precedence. Untyped arXiv papers are preprints.
+func (p paper) DocumentType() lit.DocumentType {
+	types := make(map[string]bool, len(p.PublicationTypes))
+	for _, v := range p.PublicationTypes {
+		types[v] = true
+	}
+	switch {
+	case types["Review"], types["MetaAnalysis"]:
+		return lit.TypeReview
+	case types["Conference"]:
+		return lit.TypeConferencePaper
+	case types["JournalArticle"]:
+		return lit.TypeArticle
+	case types["BookSection"]:
+		return lit.TypeBookChapter
+	case types["Book"]:
+		return lit.TypeBook
+	case len(types) > 0:
+		return lit.TypeOther
+	case p.ExternalIDs["ArXiv"] != "":
+		return lit.TypePreprint
+	default:
+		return ""
+	}
+}
+
+func (p paper) PublicationName() string {
+	if v := p.PublicationVenue; v != nil && v.Name != "" {
+		return v.Name
+	}
+	if j := p.Journal; j != nil && j.Name != "" {
+		return j.Name
+	}
+	return p.Venue
+}
+
+func (p paper) Values() map[string]string {
+	var v venue
+	if p.PublicationVenue != nil {
+		v = *p.PublicationVenue
+	}
+	var j journal
+	if p.Journal != nil {
+		j = *p.Journal
+	}
+	var pdf string
+	if p.OpenAccessPdf != nil {
+		pdf = p.OpenAccessPdf.URL
+	}
+
+	return map[string]string{
+		KeyPaperID:         p.PaperID,
+		KeyCorpusID:        p.ExternalIDs["CorpusId"],
+		KeyArxivID:         p.ExternalIDs["ArXiv"],
+		KeyPMID:            p.ExternalIDs["PubMed"],
+		KeyDOI:             p.ExternalIDs["DOI"],
+		KeyLinkAbstract:    p.URL,
+		KeyLinkPDF:         pdf,
+		KeyIssn:            v.Issn,
+		KeyPublisher:       v.Publisher,
+		KeyAuthors:         p.AuthorNames(),
+		KeyPublicationName: p.PublicationName(),
+		KeyVenueType:       v.Type,
+		KeyVolume:          strings.TrimSpace(j.Volume),
+		KeyPageRange:       strings.Join(strings.Fields(j.Pages), ""),
+		KeyPublicationType: strings.Join(p.PublicationTypes, ", "),
+		KeyFieldsOfStudy:   strings.Join(p.FieldsOfStudy, ", "),
+		KeyCitedByCount:    fmt.Sprintf("%d", p.CitationCount),
+		KeyReferenceCount:  fmt.Sprintf("%d", p.ReferenceCount),
+	}
+}
+
+// GetAbstract merges the abstract with the machine generated TL;DR summary,
+// which is often available when the publisher does not allow the abstract
+// to be redistributed.
+func (p paper) GetAbstract() (lit.Abstract, bool) {
+	var summary string
+	if p.TLDR != nil {
+		summary = strings.TrimSpace(p.TLDR.Text)
+	}
+	abstract := strings.TrimSpace(p.Abstract)
+
+	switch {
+	case abstract != "" && summary != "":
+		return lit.Abstract{Text: abstract + " TL;DR: " + summary}, true
+	case abstract != "":
+		return lit.Abstract{Text: abstract}, true
+	case summary != "":
+		return lit.Abstract{Text: "TL;DR: " + summary}, true
+	default:
+		return lit.Abstract{}, false
+	}
+}
+
+func parsePaper(b []byte) (lit.Publication, error) {
+	var p paper
+	if err := json.Unmarshal(b, &p); err != nil {
+		return lit.Publication{}, err
+	}
+	coverDate, err := p.CoverDate()
+	if err != nil {
+		return lit.Publication{}, fmt.Errorf("paper %s: %w", p.PaperID, err)
+	}
+
+	values := p.Values()
+	citedBy := p.CitationCount
+	pub := lit.Publication{
+		Title:     p.Title,
+		CoverDate: coverDate,
+		Creator:   p.Creator(),
+		Authors:   p.AuthorList(),
+		IDs: lit.Identifiers{
+			DOI:   p.ExternalIDs["DOI"],
+			PMID:  p.ExternalIDs["PubMed"],
+			ArXiv: p.ExternalIDs["ArXiv"],
+		},
+		Venue:   values[KeyPublicationName],
+		Volume:  values[KeyVolume],
+		Pages:   values[KeyPageRange],
+		Type:    p.DocumentType(),
+		CitedBy: &citedBy,
+		Values:  values,
+	}
+	if abs, ok := p.GetAbstract(); ok {
+		pub.Abstract = &abs
+	}
+	return pub, nil
+}
+
+func (c Client) ParsePublication(b lit.Blob) (lit.Publication, error) {
+	return parsePaper([]byte(b))
+}
+
+func paperID(p lit.Publication) (string, error) {
+	if id := p.Values[KeyPaperID]; id != "" {
+		return id, nil
+	}
+	// Semantic Scholar resolves external identifiers too, allowing it to
+	// serve publications coming from other libraries.
+	if doi := p.DOI(); doi != "" {
+		return "DOI:" + doi, nil
+	}
+	if id := p.IDs.ArXiv; id != "" {
+		return "ARXIV:" + id, nil
+	}
+	if id := p.IDs.PMID; id != "" {
+		return "PMID:" + id, nil
+	}
+	return "", fmt.Errorf("publication %q has neither a Semantic Scholar id nor a DOI", p.Title)
+}
+
+func (c Client) GetAbstract(ctx context.Context, p lit.Publication) (lit.Abstract, error) {
+	if p.Abstract != nil {
+		return *p.Abstract, nil
+	}
+
+	id, err := paperID(p)
+	if err != nil {
+		return lit.Abstract{}, err
+	}
+	var pp paper
+	if err := c.get(c.newRequest(ctx, "/paper/"+url.PathEscape(id), nil), &pp); err != nil {
+		return lit.Abstract{}, err
+	}
+	abs, ok := pp.GetAbstract()
+	if !ok {
+		return lit.Abstract{}, fmt.Errorf("paper %s has no abstract", pp.PaperID)
+	}
+	return abs, nil
+}
+
+type edge struct {
+	CitingPaper json.RawMessage `json:"citingPaper"`
+	CitedPaper  json.RawMessage `json:"citedPaper"`
+}
+
+type edgePage struct {
+	Offset int    `json:"offset"`
+	Next   *int   `json:"next"`
+	Data   []edge `json:"data"`
+}
+
+// edgesPerPage is the maximum page size of the citations and references
+// endpoints.
+const edgesPerPage = 1000
+
+func (c Client) edges(ctx context.Context, p lit.Publication, kind string, paper func(edge) json.RawMessage) ([]lit.Publication, error) {
+	id, err := paperID(p)
+	if err != nil {
+		return nil, err
+	}
+	path := "/paper/" + url.PathEscape(id) + "/" + kind
+
+	pubs := []lit.Publication{}
+	offset := 0
+	for {
+		q := url.Values{}
+		q.Set("offset", fmt.Sprintf("%d", offset))
+		q.Set("limit", fmt.Sprintf("%d", edgesPerPage))
+
+		var page edgePage
+		if err := c.get(c.newRequest(ctx, path, q), &page); err != nil {
+			return nil, fmt.Errorf("%s of %s: %w", kind, id, err)
+		}
+		for _, v := range page.Data {
+			raw := paper(v)
+			// Papers unknown to Semantic Scholar come with a null
+			// paperId and little else.
+			var head struct {
+				PaperID string `json:"paperId"`
+			}
+			if err := json.Unmarshal(raw, &head); err != nil || head.PaperID == "" {
+				continue
+			}
+			pp, err := c.ParsePublication(lit.Blob(raw))
+			if err != nil {
+				return nil, fmt.Errorf("%s of %s: %w", kind, id, err)
+			}
+			pubs = append(pubs, pp)
+		}
+		if page.Next == nil || len(page.Data) == 0 {
+			return pubs, nil
+		}
+		offset = *page.Next
+	}
+}
+
+// Citations returns the publications citing p, see lit.CitationProvider.
+// Used for forward snowballing.
+func (c Client) Citations(ctx context.Context, p lit.Publication) ([]lit.Publication, error) {
+	return c.edges(ctx, p, "citations", func(e edge) json.RawMessage {
+		return e.CitingPaper
+	})
+}
+
+// References returns the publications cited by p, see
+// lit.CitationProvider. Used for backward snowballing.
+func (c Client) References(ctx context.Context, p lit.Publication) ([]lit.Publication, error) {
+	return c.edges(ctx, p, "references", func(e edge) json.RawMessage {
+		return e.CitedPaper
+	})
+}
+
+// HTTPClient returns the client used to talk to the API, see
+// lit.HTTPLibrary.
+func (c Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+func (c Client) GetName() string {
+	return "Semantic Scholar"
+}
+
+// NativeID returns the Semantic Scholar paper ID of p.
+func (c Client) NativeID(p lit.Publication) string {
+	return p.Values[KeyPaperID]
+}
+
+func hasType(p lit.Publication, t string) bool {
+	for _, v := range strings.Split(p.Values[KeyPublicationType], ", ") {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Client) ToBibTeX(p lit.Publication) bibtex.Reference {
+	entry := p.BibTeXEntry()
+	venue := p.Values[KeyPublicationName]
+
+	switch {
+	case hasType(p, "Conference") || p.Values[KeyVenueType] == "conference":
+		return bibtex.InProceedings{
+			Entry:     entry,
+			BookTitle: venue,
+			PageRange: p.Value(KeyPageRange),
+			Publisher: p.Value(KeyPublisher),
+		}
+	case hasType(p, "BookSection"):
+		return bibtex.InCollection{
+			Entry:     entry,
+			BookTitle: venue,
+			Publisher: p.Values[KeyPublisher],
+			PageRange: p.Value(KeyPageRange),
+		}
+	case hasType(p, "Book"):
+		return bibtex.Book{
+			Entry:     entry,
+			Publisher: p.Values[KeyPublisher],
+		}
+	case hasType(p, "JournalArticle") && venue != "":
+		return bibtex.Article{
+			Entry:     entry,
+			Journal:   venue,
+			Volume:    p.Value(KeyVolume),
+			PageRange: p.Value(KeyPageRange),
+		}
+	default:
+		var note *string
+		if id := p.Values[KeyArxivID]; id != "" {
+			s := "arXiv:" + id
+			note = &s
+		}
+		return bibtex.Misc{
+			Entry: entry,
+			Note:  note,
+		}
+	}
+}
+
+func (c Client) ReferenceLink(p lit.Publication) string {
+	if doi := p.DOI(); doi != "" {
+		return "https://doi.org/" + doi
+	}
+	return p.Values[KeyLinkAbstract]
+}
+
+// NewClient returns a client for the Semantic Scholar Academic Graph API.
+// The apiKey is optional: anonymous requests are served from a shared,
+// heavily throttled pool.
+func NewClient(apiKey string) Client {
+	tr := &http.Transport{
+		MaxIdleConns:       10,
+		IdleConnTimeout:    15 * time.Second,
+		DisableCompression: false,
+	}
+
+	return Client{
+		apiKey:     apiKey,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Transport: tr},
+	}
+}
@@ -0,0 +1,282 @@
+package semanticscholar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jecoz/lit"
+	"github.com/jecoz/lit/bibtex"
+	"github.com/jecoz/lit/littest"
+	"github.com/jecoz/lit/middleware"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c := NewClient("secret")
+	c.endpoint = srv.URL
+	return c
+}
+
+func TestGetLiterature(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have := r.Header.Get("x-api-key"); have != "secret" {
+			t.Errorf("api key: have %q", have)
+		}
+		if r.URL.Path != "/paper/search" {
+			t.Errorf("path: have %q", r.URL.Path)
+		}
+		q := r.URL.Query()
+		want := map[string]string{
+			"query":  "fpga cnn",
+			"offset": "20",
+			"limit":  "10",
+		}
+		for k, v := range want {
+			if have := q.Get(k); have != v {
+				t.Errorf("%s: have %q, want %q", k, have, v)
+			}
+		}
+		fmt.Fprint(w, searchPage)
+	})
+
+	resp, err := c.GetLiterature(context.Background(), lit.Request{
+		Query:   "fpga cnn",
+		Page:    2,
+		PerPage: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Len() != 2 {
+		t.Fatalf("blobs: have %d, want 2", resp.Len())
+	}
+
+	p, err := c.ParsePublication(resp.Blobs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2017, 2, 22, 0, 0, 0, 0, time.UTC); !p.CoverDate.Equal(want) {
+		t.Fatalf("cover date: have %v, want %v", p.CoverDate, want)
+	}
+	values := map[string]string{
+		KeyPaperID:         "0b3c1a9b1d2e",
+		KeyCorpusID:        "3331231",
+		KeyDOI:             "10.1145/3020078.3021740",
+		KeyAuthors:         "E. Nurvitadhi and Ganesh Venkatesh",
+		KeyPublicationName: "Symposium on Field Programmable Gate Arrays",
+		KeyPageRange:       "5-14",
+	}
+	for k, want := range values {
+		if have := p.Values[k]; have != want {
+			t.Errorf("%s: have %q, want %q", k, have, want)
+		}
+	}
+	if want := "GPUs are the norm. TL;DR: FPGAs can beat GPUs."; p.Abstract == nil || p.Abstract.Text != want {
+		t.Fatalf("abstract: have %+v, want %q", p.Abstract, want)
+	}
+	if ref := c.ToBibTeX(p); ref.EntryType() != bibtex.EntryTypeInProceedings {
+		t.Fatalf("entry type: have %q, want %q", ref.EntryType(), bibtex.EntryTypeInProceedings)
+	}
+	if p.IDs.DOI != values[KeyDOI] || p.Type != lit.TypeConferencePaper || len(p.Authors) != 2 || p.Pages != "5-14" {
+		t.Fatalf("unexpected metadata %+v, %s, %+v, %q", p.IDs, p.Type, p.Authors, p.Pages)
+	}
+
+	// No abstract, only the TL;DR.
+	p, err = c.ParsePublication(resp.Blobs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "TL;DR: A design flow for CNNs."; p.Abstract == nil || p.Abstract.Text != want {
+		t.Fatalf("abstract: have %+v, want %q", p.Abstract, want)
+	}
+	if ref := c.ToBibTeX(p); ref.EntryType() != bibtex.EntryTypeArticle || ref.Fields()["volume"] != "37" {
+		t.Fatalf("unexpected article: %v %v", ref.EntryType(), ref.Fields())
+	}
+}
+
+func TestSearchWindow(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if have := r.URL.Query().Get("limit"); have != "100" {
+			t.Errorf("limit: have %q, want 100", have)
+		}
+		fmt.Fprint(w, searchPage)
+	})
+	ctx := context.Background()
+	if _, err := c.GetLiterature(ctx, lit.Request{Query: "fpga", Page: 3, PerPage: 300}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetLiterature(ctx, lit.Request{Query: "fpga", Page: 10, PerPage: 100}); err == nil {
+		t.Fatal("expected an error past the search window")
+	}
+	// The page counts 1532 results, only the ones within the window
+	// are reported.
+	c = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, searchPage)
+	})
+	if max, err := c.GetMaxLiterature(ctx, lit.Request{Query: "fpga"}); err != nil || max != 1000 {
+		t.Fatalf("have %d results, %v, want 1000", max, err)
+	}
+}
+
+func TestCitations(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/paper/DOI:10.1145/3020078.3021740/citations"; r.URL.Path != want {
+			t.Errorf("path: have %q, want %q", r.URL.Path, want)
+		}
+		switch offset := r.URL.Query().Get("offset"); offset {
+		case "0":
+			fmt.Fprint(w, `{"offset":0,"next":1000,"data":[{"citingPaper":{"paperId":"aaa","title":"A","year":2019}},{"citingPaper":{"paperId":null,"title":"Unknown"}}]}`)
+		case "1000":
+			fmt.Fprint(w, `{"offset":1000,"data":[{"citingPaper":{"paperId":"bbb","title":"B","year":2020}}]}`)
+		default:
+			t.Errorf("unexpected offset %q", offset)
+		}
+	})
+
+	// Looked up the way commands do, through the middlewares.
+	var cp lit.CitationProvider
+	if !lit.As(middleware.Chain(c, middleware.Retry(lit.DefaultRetryPolicy)), &cp) {
+		t.Fatal("decorated client is not a lit.CitationProvider")
+	}
+	pubs, err := cp.Citations(context.Background(), lit.Publication{
+		Values: map[string]string{lit.KeyDOI: "10.1145/3020078.3021740"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubs) != 2 {
+		t.Fatalf("citations: have %d, want 2", len(pubs))
+	}
+	if pubs[1].Title != "B" || pubs[1].CoverDate.Year() != 2020 {
+		t.Fatalf("unexpected publication %+v", pubs[1])
+	}
+}
+
+func TestReferences(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/paper/0b3c1a9b1d2e/references"; r.URL.Path != want {
+			t.Errorf("path: have %q, want %q", r.URL.Path, want)
+		}
+		fmt.Fprint(w, `{"offset":0,"data":[{"citedPaper":{"paperId":"ccc","title":"C","year":2012}}]}`)
+	})
+	pubs, err := c.References(context.Background(), lit.Publication{
+		Values: map[string]string{KeyPaperID: "0b3c1a9b1d2e"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubs) != 1 || pubs[0].Title != "C" {
+		t.Fatalf("unexpected references %+v", pubs)
+	}
+}
+
+func TestPaperID(t *testing.T) {
+	tt := []struct {
+		p    lit.Publication
+		want string
+	}{
+		{lit.Publication{Values: map[string]string{KeyPaperID: "0b3c1a9b1d2e"}, IDs: lit.Identifiers{DOI: "10.1/x"}}, "0b3c1a9b1d2e"},
+		{lit.Publication{IDs: lit.Identifiers{DOI: "10.1/x", ArXiv: "1706.03762"}}, "DOI:10.1/x"},
+		{lit.Publication{Values: map[string]string{lit.KeyDOI: "10.1/x"}}, "DOI:10.1/x"},
+		{lit.Publication{IDs: lit.Identifiers{ArXiv: "1706.03762", PMID: "29950123"}}, "ARXIV:1706.03762"},
+		{lit.Publication{IDs: lit.Identifiers{PMID: "29950123"}}, "PMID:29950123"},
+	}
+	for _, v := range tt {
+		if have, err := paperID(v.p); err != nil || have != v.want {
+			t.Errorf("%+v: have %q, %v, want %q", v.p, have, err, v.want)
+		}
+	}
+	if _, err := paperID(lit.Publication{IDs: lit.Identifiers{EID: "2-s2.0-85016079383"}}); err == nil {
+		t.Error("expected an error without a known identifier")
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"Unrecognized or unsupported fields: [foo]"}`)
+	})
+	_, err := c.GetMaxLiterature(context.Background(), lit.Request{Query: "fpga"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "400 Bad Request: Unrecognized or unsupported fields: [foo]"; err.Error() != want {
+		t.Fatalf("error: have %q, want %q", err, want)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	var page struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(searchPage), &page); err != nil {
+		t.Fatal(err)
+	}
+	papers := littest.Records(42, page.Data, func(i int, p map[string]interface{}) {
+		p["paperId"] = fmt.Sprintf("%040x", i)
+		p["externalIds"] = map[string]interface{}{"DOI": fmt.Sprintf("10.1145/3020078.%d", i)}
+		p["title"] = fmt.Sprintf("%s, part %d", p["title"], i)
+	})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total":  len(papers),
+			"offset": offset,
+			"data":   littest.Page(papers, offset, limit),
+		})
+	})
+	littest.RunConformance(t, c, littest.Fixture{Query: "fpga", PerPage: 10, Max: 42})
+}
+
+// Trimmed down response of the paper search endpoint, anonymized.
+const searchPage = `{
+  "total": 1532,
+  "offset": 20,
+  "next": 30,
+  "data": [
+    {
+      "paperId": "0b3c1a9b1d2e",
+      "externalIds": {"DOI": "10.1145/3020078.3021740", "MAG": "2588297539", "CorpusId": 3331231},
+      "url": "https://www.semanticscholar.org/paper/0b3c1a9b1d2e",
+      "title": "Can FPGAs Beat GPUs in Accelerating Next-Generation Deep Neural Networks?",
+      "abstract": "GPUs are the norm.",
+      "tldr": {"model": "tldr@v2.0.0", "text": "FPGAs can beat GPUs."},
+      "venue": "Symposium on Field Programmable Gate Arrays",
+      "publicationVenue": {"id": "x", "name": "Symposium on Field Programmable Gate Arrays", "type": "conference"},
+      "year": 2017,
+      "publicationDate": "2017-02-22",
+      "journal": {"name": "Proceedings of the 2017 ACM/SIGDA", "pages": "5 - 14"},
+      "publicationTypes": ["JournalArticle", "Conference"],
+      "authors": [{"authorId": "1", "name": "E. Nurvitadhi"}, {"authorId": "2", "name": "Ganesh Venkatesh"}],
+      "citationCount": 501,
+      "referenceCount": 30
+    },
+    {
+      "paperId": "9f8e7d",
+      "externalIds": {"DOI": "10.1109/TCAD.2017.2705069", "CorpusId": 4123},
+      "url": "https://www.semanticscholar.org/paper/9f8e7d",
+      "title": "Angel-Eye: A Complete Design Flow for Mapping CNN Onto Embedded FPGA",
+      "abstract": null,
+      "tldr": {"model": "tldr@v2.0.0", "text": "A design flow for CNNs."},
+      "venue": "IEEE Transactions on Computer-Aided Design of Integrated Circuits and Systems",
+      "publicationVenue": null,
+      "year": 2018,
+      "publicationDate": null,
+      "journal": {"name": "IEEE Transactions on Computer-Aided Design of Integrated Circuits and Systems", "volume": "37", "pages": "35-47"},
+      "publicationTypes": ["JournalArticle"],
+      "authors": [{"authorId": "3", "name": "Kaiyuan Guo"}],
+      "citationCount": 390,
+      "referenceCount": 41
+    }
+  ]
+}`
@@ -0,0 +1,133 @@
+package lit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Slicer is implemented by libraries capping the number of results of a
+// query that can be downloaded, whose queries can be restricted to a range
+// of publication years to stay within the cap.
+type Slicer interface {
+	// ResultLimit returns the number of results of a query that can be
+	// downloaded efficiently.
+	ResultLimit() int
+	// SliceQuery restricts query to the publications of the years from
+	// to to, inclusive. A zero from or to leaves the range open.
+	SliceQuery(query string, from, to int) string
+}
+
+// Windowed is implemented by libraries whose search reaches only the
+// first results of a query, with no way to slice it: GetMaxLiterature
+// reports at most Window of them, so that every result counted can be
+// downloaded.
+type Windowed interface {
+	Window() int
+}
+
+// Slice is a query restricted to a range of publication years, along with
+// its hits.
+type Slice struct {
+	Query    string
+	From, To int
+	Max      int
+}
+
+func (s Slice) String() string {
+	switch {
+	case s.From == 0:
+		return fmt.Sprintf("-%d", s.To)
+	case s.To == 0:
+		return fmt.Sprintf("%d-", s.From)
+	case s.From == s.To:
+		return fmt.Sprintf("%d", s.From)
+	default:
+		return fmt.Sprintf("%d-%d", s.From, s.To)
+	}
+}
+
+// SliceError tells that the hits of the slices of a query do not add up
+// to the hits of the query, e.g. as some publications have no year.
+type SliceError struct {
+	Total int
+	Sum   int
+}
+
+func (e *SliceError) Error() string {
+	return fmt.Sprintf("year slices hold %d hits, the query %d", e.Sum, e.Total)
+}
+
+// FirstSliceYear is the first year given a slice of its own. Publications
+// from before are grouped in a single slice.
+const FirstSliceYear = 1970
+
+// SliceByYear splits query, which has total hits, into slices each one
+// within the result limit of lib, which must be a Slicer. Year ranges are
+// halved until they fit: a single year exceeding the limit is kept as is.
+// Empty slices are dropped. When the hits of the slices do not add up to
+// total, the slices are returned along with a *SliceError.
+func SliceByYear(ctx context.Context, lib Library, query string, total int) ([]Slice, error) {
+	var s Slicer
+	if !As(lib, &s) {
+		return nil, fmt.Errorf("%s does not support slicing queries by year", lib.GetName())
+	}
+	limit := s.ResultLimit()
+
+	slices := []Slice{}
+	count := func(from, to int) (Slice, error) {
+		q := s.SliceQuery(query, from, to)
+		n, err := lib.GetMaxLiterature(ctx, Request{Query: q})
+		if err != nil {
+			return Slice{}, fmt.Errorf("slice %d-%d: %w", from, to, err)
+		}
+		return Slice{Query: q, From: from, To: to, Max: n}, nil
+	}
+	var split func(from, to int) error
+	split = func(from, to int) error {
+		v, err := count(from, to)
+		if err != nil {
+			return err
+		}
+		if v.Max == 0 {
+			return nil
+		}
+		if v.Max <= limit || from == to {
+			slices = append(slices, v)
+			return nil
+		}
+		mid := from + (to-from)/2
+		if err := split(from, mid); err != nil {
+			return err
+		}
+		return split(mid+1, to)
+	}
+
+	last := time.Now().Year() + 1
+	before, err := count(0, FirstSliceYear-1)
+	if err != nil {
+		return nil, err
+	}
+	if before.Max > 0 {
+		slices = append(slices, before)
+	}
+	if err := split(FirstSliceYear, last); err != nil {
+		return nil, err
+	}
+	after, err := count(last+1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if after.Max > 0 {
+		slices = append(slices, after)
+	}
+
+	sum := 0
+	for _, v := range slices {
+		sum += v.Max
+	}
+	if sum != total {
+		return slices, &SliceError{Total: total, Sum: sum}
+	}
+	return slices, nil
+}
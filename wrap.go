@@ -0,0 +1,38 @@
+package lit
+
+import "reflect"
+
+// Wrapper is implemented by libraries decorating another one, e.g. to add
+// logging or rate limiting, see package middleware.
+type Wrapper interface {
+	// Unwrap returns the library decorated.
+	Unwrap() Library
+}
+
+// As finds the first library of the chain of lib, obtained unwrapping it
+// repeatedly, implementing the interface target points to. If one is found
+// target is set to it and As returns true. Optional interfaces such as
+// Slicer or QuotaReporter must be looked up through As rather than type
+// assertions, which do not see past wrappers.
+func As(lib Library, target interface{}) bool {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		panic("lit: target must be a non-nil pointer")
+	}
+	t := v.Type().Elem()
+	if t.Kind() != reflect.Interface {
+		panic("lit: *target must be an interface type")
+	}
+	for lib != nil {
+		if reflect.TypeOf(lib).Implements(t) {
+			v.Elem().Set(reflect.ValueOf(lib))
+			return true
+		}
+		w, ok := lib.(Wrapper)
+		if !ok {
+			return false
+		}
+		lib = w.Unwrap()
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+// Package xmlfmt formats XML documents for human consumption.
+package xmlfmt
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+func name(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+const (
+	wroteNothing = iota
+	wroteStart
+	wroteText
+	wroteEnd
+)
+
+// Indent appends to dst an indented form of the XML-encoded src, in the
+// same fashion as json.Indent. Elements containing only text are kept on a
+// single line, namespace prefixes are preserved as found in the input.
+func Indent(dst *bytes.Buffer, src []byte, prefix, indent string) error {
+	d := xml.NewDecoder(bytes.NewReader(src))
+	d.Strict = false
+
+	var (
+		depth int
+		last  = wroteNothing
+	)
+	newline := func() {
+		if last == wroteNothing {
+			return
+		}
+		dst.WriteString("\n" + prefix + strings.Repeat(indent, depth))
+	}
+
+	for {
+		t, err := d.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("indent xml: %w", err)
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			newline()
+			dst.WriteString("<" + name(t.Name))
+			for _, a := range t.Attr {
+				fmt.Fprintf(dst, " %s=\"%s\"", name(a.Name), attrEscaper.Replace(a.Value))
+			}
+			dst.WriteString(">")
+			depth++
+			last = wroteStart
+		case xml.EndElement:
+			depth--
+			if last == wroteEnd {
+				newline()
+			}
+			dst.WriteString("</" + name(t.Name) + ">")
+			last = wroteEnd
+		case xml.CharData:
+			text := strings.TrimSpace(string(t))
+			if text == "" {
+				continue
+			}
+			if last == wroteEnd {
+				newline()
+			}
+			dst.WriteString(textEscaper.Replace(text))
+			last = wroteText
+		case xml.Comment:
+			newline()
+			dst.WriteString("<!--" + string(t) + "-->")
+			last = wroteEnd
+		case xml.ProcInst:
+			newline()
+			dst.WriteString("<?" + t.Target + " " + string(t.Inst) + "?>")
+			last = wroteEnd
+		case xml.Directive:
+			newline()
+			dst.WriteString("<!" + string(t) + ">")
+			last = wroteEnd
+		}
+	}
+	if depth != 0 {
+		return fmt.Errorf("indent xml: unexpected end of input")
+	}
+	return nil
+}